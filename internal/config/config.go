@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -11,6 +13,12 @@ import (
 
 const (
 	defaultCheckerAddr = "localhost:50051"
+
+	// defaultGRPCHealthCheckInterval and defaultGRPCUnhealthyTTL mirror the
+	// grpc package's own defaults (internal/grpc/health.go), duplicated here
+	// since config cannot import grpc (grpc already imports config).
+	defaultGRPCHealthCheckInterval = 10 * time.Second
+	defaultGRPCUnhealthyTTL        = 5 * time.Second
 )
 
 // Config holds the base configuration
@@ -22,6 +30,12 @@ type Config struct {
 	Github   GithubConfig
 	Telegram TelegramConfig
 	GRPC     GRPCConfig
+
+	// LoadErrors collects non-fatal validation errors found while parsing
+	// environment configuration (e.g. a malformed RAYPING_CHECKER_REATTACH
+	// entry) so callers can surface them instead of silently falling back
+	// to defaults.
+	LoadErrors []error
 }
 
 type ServerConfig struct {
@@ -69,13 +83,16 @@ type TelegramConfig struct {
 }
 
 type GRPCConfig struct {
-	CheckerServiceAddr string // Deprecated: use CheckerNodes instead
-	CheckerNodes       []CheckerNodeConfig
-	Timeout            time.Duration
-	MaxConcurrent      int
-	AggregateMode      bool // If true, send each config to all workers for redundancy; if false, distribute efficiently
-	APIKey             string
-	TLS                GRPCTLSConfig
+	CheckerServiceAddr  string // Deprecated: use CheckerNodes instead
+	CheckerNodes        []CheckerNodeConfig
+	Timeout             time.Duration
+	MaxConcurrent       int
+	AggregateMode       bool // If true, send each config to all workers for redundancy; if false, distribute efficiently
+	APIKey              string
+	TLS                 GRPCTLSConfig
+	HealthCheckInterval time.Duration // How often unhealthy nodes are probed for re-admission
+	UnhealthyTTL        time.Duration // How long a node stays ejected after a failure
+	Retry               GRPCRetryConfig
 }
 
 type GRPCTLSConfig struct {
@@ -84,14 +101,76 @@ type GRPCTLSConfig struct {
 	CAFile   string
 }
 
+// GRPCRetryConfig controls transparent retry of transient stream failures.
+// Defaults mirror common gRPC exponential backoff defaults.
+type GRPCRetryConfig struct {
+	MaxAttempts    int
+	InitialDelay   time.Duration
+	MaxDelay       time.Duration
+	Multiplier     float64
+	JitterFraction float64
+}
+
 type CheckerNodeConfig struct {
-	Addr string
-	Tag  string
+	Addr     string
+	Tag      string
+	PoolSize int // Number of pooled gRPC connections per node
+
+	// Weight biases dispatch towards this node: the smooth round-robin
+	// balancer used by CheckConfigsWithAffinity picks it roughly Weight times
+	// as often as a Weight-1 node, and the default CheckConfigs path divides
+	// its cost-based scheduling score by Weight so it's preferred by the
+	// same proportion. Zero and negative values are treated as 1.
+	Weight int
+
+	// Region pins this node for affinity-aware dispatch (see
+	// GRPCCore.CheckConfigsWithAffinity), e.g. "ir" or "eu". Empty means the
+	// node only participates in the region-agnostic weighted pool.
+	Region string
+
+	// Source records where this node came from: "env" (GRPC_CHECKER_NODES),
+	// "legacy" (GRPC_CHECKER_SERVICE_ADDR fallback), "reattach"
+	// (RAYPING_CHECKER_REATTACH), or "file" (checker_nodes in a file read by
+	// LoadFromFile). Used for observability in logs and stats.
+	Source string
+
+	// Network, APIKey and TLS are only populated for reattached nodes,
+	// whose handshake carries its own per-node credentials instead of the
+	// GRPCConfig-wide defaults.
+	Network string
+	APIKey  string
+	TLS     *CheckerNodeTLSConfig
+}
+
+// CheckerNodeTLSConfig is the per-node TLS override carried by a
+// RAYPING_CHECKER_REATTACH handshake entry.
+type CheckerNodeTLSConfig struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	Insecure bool
+}
+
+// reattachHandshake mirrors the JSON shape of RAYPING_CHECKER_REATTACH:
+// a map of tag -> {addr, network, tls: {ca, cert, key, insecure}, api_key}.
+type reattachHandshake map[string]struct {
+	Addr    string `json:"addr"`
+	Network string `json:"network"`
+	TLS     struct {
+		CA       string `json:"ca"`
+		Cert     string `json:"cert"`
+		Key      string `json:"key"`
+		Insecure bool   `json:"insecure"`
+	} `json:"tls"`
+	APIKey string `json:"api_key"`
 }
 
 // Load loads configuration from environment variables with defaults value
 func Load() *Config {
+	checkerNodes, loadErrs := parseCheckerNodes()
+
 	return &Config{
+		LoadErrors: loadErrs,
 		Server: ServerConfig{
 			Port:         getEnv("SERVER_PORT", "8080"),
 			Host:         getEnv("SERVER_HOST", ""),
@@ -131,12 +210,21 @@ func Load() *Config {
 			SendingInterval: getEnvDuration("TELEGRAM_SENDING_INTERVAL", 10*time.Second),
 		},
 		GRPC: GRPCConfig{
-			CheckerServiceAddr: getEnv("GRPC_CHECKER_SERVICE_ADDR", defaultCheckerAddr),
-			CheckerNodes:       parseCheckerNodes(),
-			Timeout:            getEnvDuration("GRPC_TIMEOUT", 5*time.Minute),
-			MaxConcurrent:      getEnvInt("GRPC_MAX_CONCURRENT", 0),
-			AggregateMode:      getEnvBool("GRPC_AGGREGATE_MODE", false), // Default to efficient distribution
-			APIKey:             getEnv("GRPC_API_KEY", ""),
+			CheckerServiceAddr:  getEnv("GRPC_CHECKER_SERVICE_ADDR", defaultCheckerAddr),
+			CheckerNodes:        checkerNodes,
+			Timeout:             getEnvDuration("GRPC_TIMEOUT", 5*time.Minute),
+			MaxConcurrent:       getEnvInt("GRPC_MAX_CONCURRENT", 0),
+			AggregateMode:       getEnvBool("GRPC_AGGREGATE_MODE", false), // Default to efficient distribution
+			APIKey:              getEnv("GRPC_API_KEY", ""),
+			HealthCheckInterval: getEnvDuration("GRPC_HEALTH_CHECK_INTERVAL", defaultGRPCHealthCheckInterval),
+			UnhealthyTTL:        getEnvDuration("GRPC_UNHEALTHY_TTL", defaultGRPCUnhealthyTTL),
+			Retry: GRPCRetryConfig{
+				MaxAttempts:    getEnvInt("GRPC_RETRY_MAX_ATTEMPTS", 5),
+				InitialDelay:   getEnvDuration("GRPC_RETRY_INITIAL_DELAY", time.Second),
+				MaxDelay:       getEnvDuration("GRPC_RETRY_MAX_DELAY", 120*time.Second),
+				Multiplier:     getEnvFloat("GRPC_RETRY_MULTIPLIER", 1.6),
+				JitterFraction: getEnvFloat("GRPC_RETRY_JITTER_FRACTION", 0.2),
+			},
 			TLS: GRPCTLSConfig{
 				CertFile: getEnv("GRPC_TLS_CERT_FILE", ""),
 				KeyFile:  getEnv("GRPC_TLS_KEY_FILE", ""),
@@ -172,6 +260,15 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -181,48 +278,164 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
-// parseCheckerNodes parses checker nodes from environment variables
-// Format: GRPC_CHECKER_NODES="addr1:tag1,addr2:tag2,addr3:tag3"
-// Example: GRPC_CHECKER_NODES="localhost:50051:node1,localhost:50052:node2"
-func parseCheckerNodes() []CheckerNodeConfig {
+// parseCheckerNodes parses checker nodes, preferring RAYPING_CHECKER_REATTACH
+// when set (see parseReattachNodes), then GRPC_CHECKER_NODES, then the
+// legacy single-node GRPC_CHECKER_SERVICE_ADDR fallback.
+//
+// GRPC_CHECKER_NODES accepts two grammars. The original positional form:
+// "addr1:tag1,addr2:tag2,addr3:tag3", e.g.
+// "localhost:50051:node1,localhost:50052:node2". And an extended key=value
+// form that also carries weight/region, with ";" separating nodes and ","
+// separating fields within one node, e.g.
+// "addr=eu.example:50051,tag=eu1,weight=3,region=eu;addr=ir.example:50051,tag=ir1,region=ir".
+// The key=value form is selected whenever the value contains an "=".
+func parseCheckerNodes() ([]CheckerNodeConfig, []error) {
+	if reattachEnv := getEnv("RAYPING_CHECKER_REATTACH", ""); reattachEnv != "" {
+		return parseReattachNodes(reattachEnv)
+	}
+
+	poolSize := getEnvInt("GRPC_CHECKER_POOL_SIZE", 1)
+
 	nodesEnv := getEnv("GRPC_CHECKER_NODES", "")
 	if nodesEnv == "" {
-		// Fallback to single node configuration for backward compatibility
-		addr := getEnv("GRPC_CHECKER_SERVICE_ADDR", defaultCheckerAddr)
-		tag := getEnv("GRPC_CHECKER_NODE_TAG", "default")
-		return []CheckerNodeConfig{
-			{
-				Addr: addr,
-				Tag:  tag,
-			},
-		}
+		return []CheckerNodeConfig{legacyCheckerNode(poolSize)}, nil
+	}
+
+	var nodes []CheckerNodeConfig
+	if strings.Contains(nodesEnv, "=") {
+		nodes = parseWeightedCheckerNodes(nodesEnv, poolSize)
+	} else {
+		nodes = parsePositionalCheckerNodes(nodesEnv, poolSize)
+	}
+
+	// If no valid nodes parsed, fallback to default
+	if len(nodes) == 0 {
+		return []CheckerNodeConfig{legacyCheckerNode(poolSize)}, nil
 	}
 
+	return nodes, nil
+}
+
+// legacyCheckerNode builds the single-node fallback used when no explicit
+// GRPC_CHECKER_NODES value (or no valid entry in it) is present.
+func legacyCheckerNode(poolSize int) CheckerNodeConfig {
+	return CheckerNodeConfig{
+		Addr:     getEnv("GRPC_CHECKER_SERVICE_ADDR", defaultCheckerAddr),
+		Tag:      getEnv("GRPC_CHECKER_NODE_TAG", "default"),
+		PoolSize: poolSize,
+		Weight:   1,
+		Source:   "legacy",
+	}
+}
+
+// parsePositionalCheckerNodes parses the original "addr:tag,addr:tag" grammar.
+func parsePositionalCheckerNodes(nodesEnv string, poolSize int) []CheckerNodeConfig {
 	var nodes []CheckerNodeConfig
-	pairs := strings.Split(nodesEnv, ",")
-	for _, pair := range pairs {
+	for _, pair := range strings.Split(nodesEnv, ",") {
 		parts := strings.Split(strings.TrimSpace(pair), ":")
 		if len(parts) >= 2 {
 			addr := strings.Join(parts[:len(parts)-1], ":")
 			tag := parts[len(parts)-1]
 			nodes = append(nodes, CheckerNodeConfig{
-				Addr: addr,
-				Tag:  tag,
+				Addr:     addr,
+				Tag:      tag,
+				PoolSize: poolSize,
+				Weight:   1,
+				Source:   "env",
 			})
 		}
 	}
+	return nodes
+}
 
-	// If no valid nodes parsed, fallback to default
-	if len(nodes) == 0 {
-		addr := getEnv("GRPC_CHECKER_SERVICE_ADDR", defaultCheckerAddr)
-		tag := getEnv("GRPC_CHECKER_NODE_TAG", "default")
-		return []CheckerNodeConfig{
-			{
-				Addr: addr,
-				Tag:  tag,
-			},
+// parseWeightedCheckerNodes parses the extended key=value grammar described
+// on parseCheckerNodes. Entries missing addr or tag are skipped.
+func parseWeightedCheckerNodes(nodesEnv string, poolSize int) []CheckerNodeConfig {
+	var nodes []CheckerNodeConfig
+	for _, entry := range strings.Split(nodesEnv, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := make(map[string]string)
+		for _, pair := range strings.Split(entry, ",") {
+			kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			fields[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+
+		if fields["addr"] == "" || fields["tag"] == "" {
+			continue
+		}
+
+		weight := 1
+		if w, err := strconv.Atoi(fields["weight"]); err == nil && w > 0 {
+			weight = w
 		}
-	}
 
+		nodes = append(nodes, CheckerNodeConfig{
+			Addr:     fields["addr"],
+			Tag:      fields["tag"],
+			PoolSize: poolSize,
+			Weight:   weight,
+			Region:   fields["region"],
+			Source:   "env",
+		})
+	}
 	return nodes
 }
+
+// parseReattachNodes parses RAYPING_CHECKER_REATTACH, a JSON object mapping
+// tag -> {addr, network, tls: {ca, cert, key, insecure}, api_key}, analogous
+// to Terraform's TF_REATTACH_PROVIDERS. Malformed JSON or entries missing
+// addr are returned as validation errors rather than silently skipped.
+func parseReattachNodes(raw string) ([]CheckerNodeConfig, []error) {
+	var handshake reattachHandshake
+	if err := json.Unmarshal([]byte(raw), &handshake); err != nil {
+		return nil, []error{fmt.Errorf("RAYPING_CHECKER_REATTACH: malformed JSON: %w", err)}
+	}
+
+	var errs []error
+	nodes := make([]CheckerNodeConfig, 0, len(handshake))
+
+	for tag, entry := range handshake {
+		if entry.Addr == "" {
+			errs = append(errs, fmt.Errorf("RAYPING_CHECKER_REATTACH: tag %q is missing addr", tag))
+			continue
+		}
+
+		node := CheckerNodeConfig{
+			Addr:     entry.Addr,
+			Tag:      tag,
+			PoolSize: 1,
+			Source:   "reattach",
+			Network:  entry.Network,
+			APIKey:   entry.APIKey,
+		}
+
+		if entry.TLS.CA != "" || entry.TLS.Cert != "" || entry.TLS.Key != "" || entry.TLS.Insecure {
+			if entry.TLS.Insecure && (entry.TLS.CA != "" || entry.TLS.Cert != "" || entry.TLS.Key != "") {
+				errs = append(errs, fmt.Errorf("RAYPING_CHECKER_REATTACH: tag %q: tls.insecure cannot be combined with ca/cert/key", tag))
+				continue
+			}
+
+			node.TLS = &CheckerNodeTLSConfig{
+				CAFile:   entry.TLS.CA,
+				CertFile: entry.TLS.Cert,
+				KeyFile:  entry.TLS.Key,
+				Insecure: entry.TLS.Insecure,
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 && len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("RAYPING_CHECKER_REATTACH: no valid entries"))
+	}
+
+	return nodes, errs
+}
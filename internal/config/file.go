@@ -0,0 +1,496 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the structured (YAML/JSON) document shape accepted by
+// LoadFromFile. Durations are plain strings parsed with time.ParseDuration,
+// matching the env var convention used by Load. Every field is optional: a
+// value left unset here falls back to its env var (and ultimately its
+// hardcoded default) exactly as Load would resolve it, except checker_nodes:
+// when neither the file, GRPC_CHECKER_NODES nor RAYPING_CHECKER_REATTACH
+// supplies nodes, CheckerNodes stays empty instead of getting Load's legacy
+// single-node fallback.
+type fileConfig struct {
+	Server   *fileServerConfig   `yaml:"server" json:"server"`
+	Worker   *fileWorkerConfig   `yaml:"worker" json:"worker"`
+	Redis    *fileRedisConfig    `yaml:"redis" json:"redis"`
+	Github   *fileGithubConfig   `yaml:"github" json:"github"`
+	App      *fileAppConfig      `yaml:"app" json:"app"`
+	Telegram *fileTelegramConfig `yaml:"telegram" json:"telegram"`
+	GRPC     *fileGRPCConfig     `yaml:"grpc" json:"grpc"`
+}
+
+type fileServerConfig struct {
+	Port         string `yaml:"port" json:"port"`
+	Host         string `yaml:"host" json:"host"`
+	ReadTimeout  string `yaml:"read_timeout" json:"read_timeout"`
+	WriteTimeout string `yaml:"write_timeout" json:"write_timeout"`
+	IdleTimeout  string `yaml:"idle_timeout" json:"idle_timeout"`
+}
+
+type fileWorkerConfig struct {
+	Count     int `yaml:"count" json:"count"`
+	QueueSize int `yaml:"queue_size" json:"queue_size"`
+}
+
+type fileRedisConfig struct {
+	Addr      string `yaml:"addr" json:"addr"`
+	Password  string `yaml:"password" json:"password"`
+	DB        int    `yaml:"db" json:"db"`
+	ResultTTL string `yaml:"result_ttl" json:"result_ttl"`
+}
+
+type fileGithubConfig struct {
+	SSHKeyPath string `yaml:"ssh_key_path" json:"ssh_key_path"`
+	Owner      string `yaml:"owner" json:"owner"`
+	Repo       string `yaml:"repo" json:"repo"`
+}
+
+type fileAppConfig struct {
+	LogLevel        string `yaml:"log_level" json:"log_level"`
+	Timeout         string `yaml:"timeout" json:"timeout"`
+	RefreshInterval string `yaml:"refresh_interval" json:"refresh_interval"`
+	MaxConcurrent   int    `yaml:"max_concurrent" json:"max_concurrent"`
+	CheckHost       string `yaml:"check_host" json:"check_host"`
+	EncryptionKey   string `yaml:"encryption_key" json:"encryption_key"`
+}
+
+type fileTelegramConfig struct {
+	BotToken        string `yaml:"bot_token" json:"bot_token"`
+	Channel         string `yaml:"channel" json:"channel"`
+	Template        string `yaml:"template" json:"template"`
+	QRConfig        string `yaml:"qr_config" json:"qr_config"`
+	ProxyURL        string `yaml:"proxy_url" json:"proxy_url"`
+	SendingInterval string `yaml:"sending_interval" json:"sending_interval"`
+}
+
+type fileGRPCConfig struct {
+	CheckerServiceAddr  string                  `yaml:"checker_service_addr" json:"checker_service_addr"`
+	CheckerNodes        []fileCheckerNodeConfig `yaml:"checker_nodes" json:"checker_nodes"`
+	Timeout             string                  `yaml:"timeout" json:"timeout"`
+	MaxConcurrent       int                     `yaml:"max_concurrent" json:"max_concurrent"`
+	AggregateMode       bool                    `yaml:"aggregate_mode" json:"aggregate_mode"`
+	APIKey              string                  `yaml:"api_key" json:"api_key"`
+	TLS                 *fileGRPCTLSConfig      `yaml:"tls" json:"tls"`
+	HealthCheckInterval string                  `yaml:"health_check_interval" json:"health_check_interval"`
+	UnhealthyTTL        string                  `yaml:"unhealthy_ttl" json:"unhealthy_ttl"`
+	Retry               *fileGRPCRetryConfig    `yaml:"retry" json:"retry"`
+}
+
+type fileCheckerNodeConfig struct {
+	Addr     string                    `yaml:"addr" json:"addr"`
+	Tag      string                    `yaml:"tag" json:"tag"`
+	PoolSize int                       `yaml:"pool_size" json:"pool_size"`
+	Weight   int                       `yaml:"weight" json:"weight"`
+	Region   string                    `yaml:"region" json:"region"`
+	Network  string                    `yaml:"network" json:"network"`
+	APIKey   string                    `yaml:"api_key" json:"api_key"`
+	TLS      *fileCheckerNodeTLSConfig `yaml:"tls" json:"tls"`
+}
+
+type fileCheckerNodeTLSConfig struct {
+	CAFile   string `yaml:"ca_file" json:"ca_file"`
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+	Insecure bool   `yaml:"insecure" json:"insecure"`
+}
+
+type fileGRPCTLSConfig struct {
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+	CAFile   string `yaml:"ca_file" json:"ca_file"`
+}
+
+type fileGRPCRetryConfig struct {
+	MaxAttempts    int     `yaml:"max_attempts" json:"max_attempts"`
+	InitialDelay   string  `yaml:"initial_delay" json:"initial_delay"`
+	MaxDelay       string  `yaml:"max_delay" json:"max_delay"`
+	Multiplier     float64 `yaml:"multiplier" json:"multiplier"`
+	JitterFraction float64 `yaml:"jitter_fraction" json:"jitter_fraction"`
+}
+
+// LoadFromFile parses a YAML or JSON config file (auto-detected from the
+// file extension: .yaml/.yml vs .json) and layers it underneath the same
+// environment variables Load reads, so an explicitly set env var always
+// wins over the file. Unlike Load, a malformed file - bad syntax or unknown
+// keys - is a hard error, since an operator-authored file is expected to be
+// correct. Values that parse but fail validateConfig, and env node-list
+// parse errors, are not returned as the error: they are collected in
+// Config.LoadErrors, same as Load.
+//
+// This tree has no cmd/ entrypoint yet (Load is only called from internal
+// package wiring), so there is nowhere to add a --config flag to; whichever
+// main package is introduced first should add one that calls LoadFromFile
+// and falls back to Load when it's unset.
+func LoadFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var fc fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(strings.NewReader(string(data)))
+		dec.KnownFields(true)
+		if err := dec.Decode(&fc); err != nil {
+			return nil, fmt.Errorf("parse yaml config: %w", err)
+		}
+	case ".json":
+		if err := jsonUnmarshalStrict(data, &fc); err != nil {
+			return nil, fmt.Errorf("parse json config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (expected .yaml, .yml, or .json)", ext)
+	}
+
+	cfg := applyFileConfig(&fc)
+	envErrs := applyEnvOverrides(cfg)
+
+	errs := append(envErrs, validateConfig(cfg)...)
+	if len(errs) > 0 {
+		cfg.LoadErrors = errs
+	}
+
+	return cfg, nil
+}
+
+// jsonUnmarshalStrict decodes JSON, rejecting unknown fields, mirroring the
+// KnownFields(true) behavior used for YAML above.
+func jsonUnmarshalStrict(data []byte, v any) error {
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// applyFileConfig builds a Config from a parsed file document, falling back
+// to the same hardcoded defaults Load uses for anything left unset.
+func applyFileConfig(fc *fileConfig) *Config {
+	cfg := &Config{
+		Server: ServerConfig{
+			Port:         "8080",
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 30 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
+		Worker: WorkerConfig{
+			Count:     5,
+			QueueSize: 100,
+		},
+		Redis: RedisConfig{
+			Addr:      "localhost:6379",
+			ResultTTL: time.Hour,
+		},
+		App: AppConfig{
+			LogLevel:        "info",
+			Timeout:         10 * time.Second,
+			CheckHost:       "1.1.1.1:80",
+			RefreshInterval: time.Hour,
+		},
+		Telegram: TelegramConfig{
+			SendingInterval: 10 * time.Second,
+		},
+		GRPC: GRPCConfig{
+			CheckerServiceAddr:  defaultCheckerAddr,
+			Timeout:             5 * time.Minute,
+			HealthCheckInterval: defaultGRPCHealthCheckInterval,
+			UnhealthyTTL:        defaultGRPCUnhealthyTTL,
+			Retry: GRPCRetryConfig{
+				MaxAttempts:    5,
+				InitialDelay:   time.Second,
+				MaxDelay:       120 * time.Second,
+				Multiplier:     1.6,
+				JitterFraction: 0.2,
+			},
+		},
+	}
+
+	if fc.Server != nil {
+		overlayString(&cfg.Server.Port, fc.Server.Port)
+		overlayString(&cfg.Server.Host, fc.Server.Host)
+		overlayDuration(&cfg.Server.ReadTimeout, fc.Server.ReadTimeout)
+		overlayDuration(&cfg.Server.WriteTimeout, fc.Server.WriteTimeout)
+		overlayDuration(&cfg.Server.IdleTimeout, fc.Server.IdleTimeout)
+	}
+	if fc.Worker != nil {
+		overlayInt(&cfg.Worker.Count, fc.Worker.Count)
+		overlayInt(&cfg.Worker.QueueSize, fc.Worker.QueueSize)
+	}
+	if fc.Redis != nil {
+		overlayString(&cfg.Redis.Addr, fc.Redis.Addr)
+		overlayString(&cfg.Redis.Password, fc.Redis.Password)
+		cfg.Redis.DB = fc.Redis.DB
+		overlayDuration(&cfg.Redis.ResultTTL, fc.Redis.ResultTTL)
+	}
+	if fc.Github != nil {
+		overlayString(&cfg.Github.SSHKeyPath, fc.Github.SSHKeyPath)
+		overlayString(&cfg.Github.Owner, fc.Github.Owner)
+		overlayString(&cfg.Github.Repo, fc.Github.Repo)
+	}
+	if fc.App != nil {
+		overlayString(&cfg.App.LogLevel, fc.App.LogLevel)
+		overlayDuration(&cfg.App.Timeout, fc.App.Timeout)
+		overlayDuration(&cfg.App.RefreshInterval, fc.App.RefreshInterval)
+		overlayInt(&cfg.App.MaxConcurrent, fc.App.MaxConcurrent)
+		overlayString(&cfg.App.CheckHost, fc.App.CheckHost)
+		overlayString(&cfg.App.EncryptionKey, fc.App.EncryptionKey)
+	}
+	if fc.Telegram != nil {
+		overlayString(&cfg.Telegram.BotToken, fc.Telegram.BotToken)
+		overlayString(&cfg.Telegram.Channel, fc.Telegram.Channel)
+		overlayString(&cfg.Telegram.Template, fc.Telegram.Template)
+		overlayString(&cfg.Telegram.QRConfig, fc.Telegram.QRConfig)
+		overlayString(&cfg.Telegram.ProxyURL, fc.Telegram.ProxyURL)
+		overlayDuration(&cfg.Telegram.SendingInterval, fc.Telegram.SendingInterval)
+	}
+	if fc.GRPC != nil {
+		overlayString(&cfg.GRPC.CheckerServiceAddr, fc.GRPC.CheckerServiceAddr)
+		overlayDuration(&cfg.GRPC.Timeout, fc.GRPC.Timeout)
+		overlayInt(&cfg.GRPC.MaxConcurrent, fc.GRPC.MaxConcurrent)
+		cfg.GRPC.AggregateMode = fc.GRPC.AggregateMode
+		overlayString(&cfg.GRPC.APIKey, fc.GRPC.APIKey)
+		overlayDuration(&cfg.GRPC.HealthCheckInterval, fc.GRPC.HealthCheckInterval)
+		overlayDuration(&cfg.GRPC.UnhealthyTTL, fc.GRPC.UnhealthyTTL)
+
+		if fc.GRPC.TLS != nil {
+			cfg.GRPC.TLS = GRPCTLSConfig{
+				CertFile: fc.GRPC.TLS.CertFile,
+				KeyFile:  fc.GRPC.TLS.KeyFile,
+				CAFile:   fc.GRPC.TLS.CAFile,
+			}
+		}
+		if fc.GRPC.Retry != nil {
+			overlayInt(&cfg.GRPC.Retry.MaxAttempts, fc.GRPC.Retry.MaxAttempts)
+			overlayDuration(&cfg.GRPC.Retry.InitialDelay, fc.GRPC.Retry.InitialDelay)
+			overlayDuration(&cfg.GRPC.Retry.MaxDelay, fc.GRPC.Retry.MaxDelay)
+			if fc.GRPC.Retry.Multiplier != 0 {
+				cfg.GRPC.Retry.Multiplier = fc.GRPC.Retry.Multiplier
+			}
+			if fc.GRPC.Retry.JitterFraction != 0 {
+				cfg.GRPC.Retry.JitterFraction = fc.GRPC.Retry.JitterFraction
+			}
+		}
+		for _, n := range fc.GRPC.CheckerNodes {
+			weight := n.Weight
+			if weight <= 0 {
+				weight = 1
+			}
+			node := CheckerNodeConfig{
+				Addr:     n.Addr,
+				Tag:      n.Tag,
+				PoolSize: n.PoolSize,
+				Weight:   weight,
+				Region:   n.Region,
+				Source:   "file",
+				Network:  n.Network,
+				APIKey:   n.APIKey,
+			}
+			if n.TLS != nil {
+				node.TLS = &CheckerNodeTLSConfig{
+					CAFile:   n.TLS.CAFile,
+					CertFile: n.TLS.CertFile,
+					KeyFile:  n.TLS.KeyFile,
+					Insecure: n.TLS.Insecure,
+				}
+			}
+			cfg.GRPC.CheckerNodes = append(cfg.GRPC.CheckerNodes, node)
+		}
+	}
+
+	return cfg
+}
+
+// applyEnvOverrides re-applies the same env vars Load reads on top of a
+// file-derived Config, so an operator can still override one field from the
+// environment without editing the file. Any error parsing a node-list
+// override (RAYPING_CHECKER_REATTACH or GRPC_CHECKER_NODES) is returned
+// rather than dropped, so LoadFromFile can surface it via LoadErrors the
+// same way Load does.
+func applyEnvOverrides(cfg *Config) []error {
+	cfg.Server.Port = getEnv("SERVER_PORT", cfg.Server.Port)
+	cfg.Server.Host = getEnv("SERVER_HOST", cfg.Server.Host)
+	cfg.Server.ReadTimeout = getEnvDuration("SERVER_READ_TIMEOUT", cfg.Server.ReadTimeout)
+	cfg.Server.WriteTimeout = getEnvDuration("SERVER_WRITE_TIMEOUT", cfg.Server.WriteTimeout)
+	cfg.Server.IdleTimeout = getEnvDuration("SERVER_IDLE_TIMEOUT", cfg.Server.IdleTimeout)
+
+	cfg.Worker.Count = getEnvInt("WORKER_COUNT", cfg.Worker.Count)
+	cfg.Worker.QueueSize = getEnvInt("WORKER_QUEUE_SIZE", cfg.Worker.QueueSize)
+
+	cfg.Redis.Addr = getEnv("REDIS_ADDR", cfg.Redis.Addr)
+	cfg.Redis.Password = getEnv("REDIS_PASSWORD", cfg.Redis.Password)
+	cfg.Redis.DB = getEnvInt("REDIS_DB", cfg.Redis.DB)
+	cfg.Redis.ResultTTL = getEnvDuration("REDIS_RESULT_TTL", cfg.Redis.ResultTTL)
+
+	cfg.Github.SSHKeyPath = getEnv("GITHUB_SSH_KEY_PATH", cfg.Github.SSHKeyPath)
+	cfg.Github.Owner = getEnv("GITHUB_OWNER", cfg.Github.Owner)
+	cfg.Github.Repo = getEnv("GITHUB_REPO", cfg.Github.Repo)
+
+	cfg.App.LogLevel = getEnv("LOG_LEVEL", cfg.App.LogLevel)
+	cfg.App.Timeout = getEnvDuration("APP_TIMEOUT", cfg.App.Timeout)
+	cfg.App.MaxConcurrent = getEnvInt("MAX_CONCURRENT", cfg.App.MaxConcurrent)
+	cfg.App.CheckHost = getEnv("CHECK_HOST", cfg.App.CheckHost)
+	cfg.App.EncryptionKey = getEnv("ENCRYPTION_KEY", cfg.App.EncryptionKey)
+	cfg.App.RefreshInterval = getEnvDuration("REFRESH_INTERVAL", cfg.App.RefreshInterval)
+
+	cfg.Telegram.BotToken = getEnv("TELEGRAM_BOT_TOKEN", cfg.Telegram.BotToken)
+	cfg.Telegram.Channel = getEnv("TELEGRAM_CHANNEL", cfg.Telegram.Channel)
+	cfg.Telegram.Template = getEnv("TELEGRAM_TEMPLATE", cfg.Telegram.Template)
+	cfg.Telegram.QRConfig = getEnv("TELEGRAM_QR_CONFIG", cfg.Telegram.QRConfig)
+	cfg.Telegram.ProxyURL = getEnv("TELEGRAM_PROXY_URL", cfg.Telegram.ProxyURL)
+	cfg.Telegram.SendingInterval = getEnvDuration("TELEGRAM_SENDING_INTERVAL", cfg.Telegram.SendingInterval)
+
+	cfg.GRPC.CheckerServiceAddr = getEnv("GRPC_CHECKER_SERVICE_ADDR", cfg.GRPC.CheckerServiceAddr)
+	cfg.GRPC.Timeout = getEnvDuration("GRPC_TIMEOUT", cfg.GRPC.Timeout)
+	cfg.GRPC.MaxConcurrent = getEnvInt("GRPC_MAX_CONCURRENT", cfg.GRPC.MaxConcurrent)
+	cfg.GRPC.AggregateMode = getEnvBool("GRPC_AGGREGATE_MODE", cfg.GRPC.AggregateMode)
+	cfg.GRPC.APIKey = getEnv("GRPC_API_KEY", cfg.GRPC.APIKey)
+	cfg.GRPC.HealthCheckInterval = getEnvDuration("GRPC_HEALTH_CHECK_INTERVAL", cfg.GRPC.HealthCheckInterval)
+	cfg.GRPC.UnhealthyTTL = getEnvDuration("GRPC_UNHEALTHY_TTL", cfg.GRPC.UnhealthyTTL)
+	cfg.GRPC.Retry.MaxAttempts = getEnvInt("GRPC_RETRY_MAX_ATTEMPTS", cfg.GRPC.Retry.MaxAttempts)
+	cfg.GRPC.Retry.InitialDelay = getEnvDuration("GRPC_RETRY_INITIAL_DELAY", cfg.GRPC.Retry.InitialDelay)
+	cfg.GRPC.Retry.MaxDelay = getEnvDuration("GRPC_RETRY_MAX_DELAY", cfg.GRPC.Retry.MaxDelay)
+	cfg.GRPC.Retry.Multiplier = getEnvFloat("GRPC_RETRY_MULTIPLIER", cfg.GRPC.Retry.Multiplier)
+	cfg.GRPC.Retry.JitterFraction = getEnvFloat("GRPC_RETRY_JITTER_FRACTION", cfg.GRPC.Retry.JitterFraction)
+	cfg.GRPC.TLS.CertFile = getEnv("GRPC_TLS_CERT_FILE", cfg.GRPC.TLS.CertFile)
+	cfg.GRPC.TLS.KeyFile = getEnv("GRPC_TLS_KEY_FILE", cfg.GRPC.TLS.KeyFile)
+	cfg.GRPC.TLS.CAFile = getEnv("GRPC_TLS_CA_FILE", cfg.GRPC.TLS.CAFile)
+
+	// GRPC_CHECKER_NODES and RAYPING_CHECKER_REATTACH, when set, replace the
+	// file's CheckerNodes entirely rather than merging - a node list is not
+	// something that can be sanely merged field by field.
+	var errs []error
+	if os.Getenv("RAYPING_CHECKER_REATTACH") != "" || os.Getenv("GRPC_CHECKER_NODES") != "" {
+		nodes, nodeErrs := parseCheckerNodes()
+		cfg.GRPC.CheckerNodes = nodes
+		errs = append(errs, nodeErrs...)
+	}
+	return errs
+}
+
+func overlayString(dst *string, val string) {
+	if val != "" {
+		*dst = val
+	}
+}
+
+func overlayInt(dst *int, val int) {
+	if val != 0 {
+		*dst = val
+	}
+}
+
+func overlayDuration(dst *time.Duration, val string) {
+	if val == "" {
+		return
+	}
+	if d, err := time.ParseDuration(val); err == nil {
+		*dst = d
+	}
+}
+
+// validateConfig checks the fully-resolved Config for values that parse
+// fine on their own but don't make sense together, returning one error per
+// problem found so operators see every issue in a single run.
+func validateConfig(cfg *Config) []error {
+	var errs []error
+
+	if cfg.GRPC.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("grpc.timeout must not be negative"))
+	}
+	if cfg.GRPC.HealthCheckInterval < 0 {
+		errs = append(errs, fmt.Errorf("grpc.health_check_interval must not be negative"))
+	}
+	if cfg.GRPC.UnhealthyTTL < 0 {
+		errs = append(errs, fmt.Errorf("grpc.unhealthy_ttl must not be negative"))
+	}
+	if cfg.App.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("app.timeout must not be negative"))
+	}
+	if cfg.Redis.ResultTTL < 0 {
+		errs = append(errs, fmt.Errorf("redis.result_ttl must not be negative"))
+	}
+
+	if cfg.GRPC.AggregateMode && len(cfg.GRPC.CheckerNodes) == 0 {
+		errs = append(errs, fmt.Errorf("grpc.aggregate_mode requires at least one entry in grpc.checker_nodes"))
+	}
+
+	for _, node := range cfg.GRPC.CheckerNodes {
+		if node.Addr == "" || node.Tag == "" {
+			errs = append(errs, fmt.Errorf("checker node entry %q is missing addr or tag", node.Addr+":"+node.Tag))
+			continue
+		}
+		if node.TLS == nil {
+			continue
+		}
+		if node.TLS.Insecure && (node.TLS.CAFile != "" || node.TLS.CertFile != "" || node.TLS.KeyFile != "") {
+			errs = append(errs, fmt.Errorf("checker node %q: tls.insecure cannot be combined with ca_file/cert_file/key_file", node.Tag))
+		}
+		if (node.TLS.CertFile == "") != (node.TLS.KeyFile == "") {
+			errs = append(errs, fmt.Errorf("checker node %q: tls.cert_file and tls.key_file must be set together", node.Tag))
+		}
+	}
+
+	return errs
+}
+
+// Watch watches path for changes and invokes onChange with a freshly loaded
+// and validated Config each time the file is modified, until ctx is
+// canceled. A reload that fails to read, parse, or validate (non-empty
+// LoadErrors) is logged to nothing here by design - callers own logging -
+// and simply skipped, leaving the previous config in place; onChange only
+// ever receives a non-nil Config with no LoadErrors.
+func Watch(ctx context.Context, path string, onChange func(*Config)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create config watcher: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watch config directory %s: %w", dir, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				cfg, err := LoadFromFile(path)
+				if err != nil || len(cfg.LoadErrors) > 0 {
+					continue
+				}
+				onChange(cfg)
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// clearGRPCEnv unsets every env var applyEnvOverrides reads for GRPC, so a
+// file-derived value isn't masked by whatever happens to be set in the test
+// process's environment.
+func clearGRPCEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"GRPC_CHECKER_SERVICE_ADDR", "GRPC_TIMEOUT", "GRPC_MAX_CONCURRENT",
+		"GRPC_AGGREGATE_MODE", "GRPC_API_KEY", "GRPC_HEALTH_CHECK_INTERVAL",
+		"GRPC_UNHEALTHY_TTL", "GRPC_RETRY_MAX_ATTEMPTS", "GRPC_RETRY_INITIAL_DELAY",
+		"GRPC_RETRY_MAX_DELAY", "GRPC_RETRY_MULTIPLIER", "GRPC_RETRY_JITTER_FRACTION",
+		"GRPC_TLS_CERT_FILE", "GRPC_TLS_KEY_FILE", "GRPC_TLS_CA_FILE",
+		"GRPC_CHECKER_NODES", "GRPC_CHECKER_POOL_SIZE", "RAYPING_CHECKER_REATTACH",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestLoadFromFileYAML(t *testing.T) {
+	clearGRPCEnv(t)
+
+	doc := `
+grpc:
+  aggregate_mode: true
+  timeout: 45s
+  api_key: yaml-key
+  checker_nodes:
+    - addr: 10.0.0.1:50051
+      tag: node1
+      weight: 3
+    - addr: 10.0.0.2:50051
+      tag: node2
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	if len(cfg.LoadErrors) != 0 {
+		t.Fatalf("LoadErrors = %v, want none", cfg.LoadErrors)
+	}
+	if !cfg.GRPC.AggregateMode {
+		t.Error("AggregateMode = false, want true")
+	}
+	if cfg.GRPC.Timeout != 45*time.Second {
+		t.Errorf("Timeout = %v, want 45s", cfg.GRPC.Timeout)
+	}
+	if cfg.GRPC.APIKey != "yaml-key" {
+		t.Errorf("APIKey = %q, want yaml-key", cfg.GRPC.APIKey)
+	}
+	if len(cfg.GRPC.CheckerNodes) != 2 {
+		t.Fatalf("CheckerNodes = %d, want 2", len(cfg.GRPC.CheckerNodes))
+	}
+	if cfg.GRPC.CheckerNodes[0].Weight != 3 {
+		t.Errorf("node1 weight = %d, want 3", cfg.GRPC.CheckerNodes[0].Weight)
+	}
+	if cfg.GRPC.CheckerNodes[1].Weight != 1 {
+		t.Errorf("node2 weight = %d, want 1 (zero defaults to 1)", cfg.GRPC.CheckerNodes[1].Weight)
+	}
+	for _, node := range cfg.GRPC.CheckerNodes {
+		if node.Source != "file" {
+			t.Errorf("node %q source = %q, want file", node.Tag, node.Source)
+		}
+	}
+}
+
+func TestLoadFromFileJSON(t *testing.T) {
+	clearGRPCEnv(t)
+
+	doc := `{
+		"grpc": {
+			"checker_service_addr": "10.0.0.9:50051",
+			"max_concurrent": 42,
+			"checker_nodes": [
+				{"addr": "10.0.0.1:50051", "tag": "node1"}
+			]
+		}
+	}`
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	if cfg.GRPC.CheckerServiceAddr != "10.0.0.9:50051" {
+		t.Errorf("CheckerServiceAddr = %q, want 10.0.0.9:50051", cfg.GRPC.CheckerServiceAddr)
+	}
+	if cfg.GRPC.MaxConcurrent != 42 {
+		t.Errorf("MaxConcurrent = %d, want 42", cfg.GRPC.MaxConcurrent)
+	}
+	if len(cfg.GRPC.CheckerNodes) != 1 || cfg.GRPC.CheckerNodes[0].Tag != "node1" {
+		t.Fatalf("CheckerNodes = %+v, want one node tagged node1", cfg.GRPC.CheckerNodes)
+	}
+}
+
+func TestLoadFromFileRejectsUnknownFields(t *testing.T) {
+	clearGRPCEnv(t)
+
+	cases := []struct {
+		name string
+		ext  string
+		doc  string
+	}{
+		{"yaml", "yaml", "grpc:\n  bogus_field: true\n"},
+		{"json", "json", `{"grpc": {"bogus_field": true}}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config."+tc.ext)
+			if err := os.WriteFile(path, []byte(tc.doc), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+			if _, err := LoadFromFile(path); err == nil {
+				t.Fatal("LoadFromFile() error = nil, want an error for an unknown field")
+			}
+		})
+	}
+}
+
+func TestLoadFromFileUnsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("grpc = {}"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := LoadFromFile(path); err == nil {
+		t.Fatal("LoadFromFile() error = nil, want an error for an unsupported extension")
+	}
+}
+
+func TestLoadFromFileEnvOverridesFile(t *testing.T) {
+	clearGRPCEnv(t)
+	t.Setenv("GRPC_API_KEY", "env-key")
+
+	doc := "grpc:\n  api_key: file-key\n"
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	if cfg.GRPC.APIKey != "env-key" {
+		t.Errorf("APIKey = %q, want the env override to win over the file", cfg.GRPC.APIKey)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	validBase := func() *Config {
+		return &Config{
+			GRPC: GRPCConfig{
+				CheckerNodes: []CheckerNodeConfig{{Addr: "10.0.0.1:50051", Tag: "node1"}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr bool
+	}{
+		{"valid config", func(*Config) {}, false},
+		{"negative grpc timeout", func(c *Config) { c.GRPC.Timeout = -time.Second }, true},
+		{"negative health check interval", func(c *Config) { c.GRPC.HealthCheckInterval = -time.Second }, true},
+		{"negative unhealthy ttl", func(c *Config) { c.GRPC.UnhealthyTTL = -time.Second }, true},
+		{"negative app timeout", func(c *Config) { c.App.Timeout = -time.Second }, true},
+		{"negative redis result ttl", func(c *Config) { c.Redis.ResultTTL = -time.Second }, true},
+		{"aggregate mode with no checker nodes", func(c *Config) {
+			c.GRPC.AggregateMode = true
+			c.GRPC.CheckerNodes = nil
+		}, true},
+		{"checker node missing tag", func(c *Config) {
+			c.GRPC.CheckerNodes = []CheckerNodeConfig{{Addr: "10.0.0.1:50051"}}
+		}, true},
+		{"checker node missing addr", func(c *Config) {
+			c.GRPC.CheckerNodes = []CheckerNodeConfig{{Tag: "node1"}}
+		}, true},
+		{"tls insecure combined with ca file", func(c *Config) {
+			c.GRPC.CheckerNodes[0].TLS = &CheckerNodeTLSConfig{Insecure: true, CAFile: "ca.pem"}
+		}, true},
+		{"tls cert without key", func(c *Config) {
+			c.GRPC.CheckerNodes[0].TLS = &CheckerNodeTLSConfig{CertFile: "cert.pem"}
+		}, true},
+		{"tls cert and key together", func(c *Config) {
+			c.GRPC.CheckerNodes[0].TLS = &CheckerNodeTLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"}
+		}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validBase()
+			tc.mutate(cfg)
+			errs := validateConfig(cfg)
+			if tc.wantErr && len(errs) == 0 {
+				t.Fatal("validateConfig() returned no errors, want at least one")
+			}
+			if !tc.wantErr && len(errs) != 0 {
+				t.Fatalf("validateConfig() = %v, want none", errs)
+			}
+		})
+	}
+}
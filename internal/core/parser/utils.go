@@ -1,11 +1,24 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
+	"syscall"
+	"time"
 )
 
+// defaultMaxResolvedIPs bounds how many IPs from a single DNS answer get
+// vetted and pinned, against hostnames with unusually large answer sets.
+const defaultMaxResolvedIPs = 8
+
+// ErrLocalhostBlocked is returned (wrapped, via errors.Is) by
+// ResolveAddress/validateAddress whenever an address is localhost, resolves
+// to a localhost/private IP, or fails strict DNS resolution.
+var ErrLocalhostBlocked = errors.New("address resolves to a disallowed localhost/private IP")
+
 // isLocalhostIP checks if an IP address is localhost/loopback
 func isLocalhostIP(ip net.IP) bool {
 	return ip.IsLoopback() || ip.Equal(net.IPv4(127, 0, 0, 1)) || ip.Equal(net.IPv6loopback)
@@ -49,43 +62,191 @@ func isPrivateIP(ip net.IP) bool {
 	return false
 }
 
-// validateAddress checks if an address is not localhost
+// unmapIP strips an IPv4-in-IPv6 mapping (e.g. ::ffff:127.0.0.1 ->
+// 127.0.0.1) so range checks can't be bypassed by an IPv4-mapped IPv6
+// literal.
+func unmapIP(ip net.IP) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		return v4
+	}
+	return ip
+}
+
+// AddressValidationConfig configures ResolveAddress/validateAddress beyond
+// the built-in localhost/private-range checks.
+type AddressValidationConfig struct {
+	// ExtraDenyCIDRs are blocked in addition to the built-in private/
+	// loopback ranges - e.g. cloud metadata services (169.254.169.254/32)
+	// or a deployment's own corp network.
+	ExtraDenyCIDRs []*net.IPNet
+
+	// AllowedHosts bypasses every range check for the given literal
+	// host/IP strings, for explicit testing against otherwise-blocked
+	// addresses.
+	AllowedHosts map[string]bool
+
+	// MaxResolvedIPs caps how many of a hostname's resolved IPs are vetted
+	// and pinned. Zero means defaultMaxResolvedIPs.
+	MaxResolvedIPs int
+
+	// Strict fails closed on a DNS resolution error instead of the
+	// historical permissive fall-through that lets the connection attempt
+	// fail later.
+	Strict bool
+
+	// AllowPrivate skips the localhost/private-range denial checks, keeping
+	// only IP pinning (plus ExtraDenyCIDRs). Set this for addresses the
+	// caller already trusts administratively - e.g. a checker node that is
+	// expected to live on a private network or localhost - where the goal
+	// is closing the DNS-rebinding TOCTOU, not SSRF-style range denial.
+	AllowPrivate bool
+}
+
+// DefaultAddressValidationConfig returns the permissive config matching
+// validateAddress's historical behavior: no extra deny ranges, no
+// allow-list, the default resolution cap, and non-strict DNS failure
+// handling.
+func DefaultAddressValidationConfig() AddressValidationConfig {
+	return AddressValidationConfig{MaxResolvedIPs: defaultMaxResolvedIPs}
+}
+
+// ResolvedAddress pins a hostname to the specific IPs vetted at validation
+// time, so a later dial (see SafeDialer) can refuse to connect to any IP a
+// hostile or rebinding DNS server substitutes between validation and
+// connect.
+type ResolvedAddress struct {
+	Host       string
+	IPs        []net.IP
+	ResolvedAt time.Time
+}
+
+// validateAddress checks if an address is not localhost, using
+// DefaultAddressValidationConfig. Kept for existing callers; new code
+// should call ResolveAddress directly to get the pinned IPs for dialing.
 func validateAddress(address string) error {
-	if address == "" {
-		return nil
+	_, err := ResolveAddress(address, DefaultAddressValidationConfig())
+	return err
+}
+
+// ResolveAddress validates address against cfg and, for a hostname,
+// resolves and pins its IPs so a caller can dial only one of the vetted
+// addresses instead of re-resolving at dial time and risking a
+// DNS-rebinding attack in between.
+func ResolveAddress(address string, cfg AddressValidationConfig) (*ResolvedAddress, error) {
+	now := time.Now()
+
+	if address == "" || cfg.AllowedHosts[address] {
+		return &ResolvedAddress{Host: address, ResolvedAt: now}, nil
 	}
 
-	// Check for obvious localhost hostnames
 	lowerAddr := strings.ToLower(address)
-	if lowerAddr == "localhost" || lowerAddr == "localhost.localdomain" {
-		return fmt.Errorf("%w: %s", ErrLocalhostBlocked, address)
+	if !cfg.AllowPrivate && (lowerAddr == "localhost" || lowerAddr == "localhost.localdomain") {
+		return nil, fmt.Errorf("%w: %s", ErrLocalhostBlocked, address)
 	}
 
 	// parse as IP first
 	if ip := net.ParseIP(address); ip != nil {
-		if isLocalhostIP(ip) {
-			return fmt.Errorf("%w: %s", ErrLocalhostBlocked, address)
+		if err := validateResolvedIP(address, ip, cfg); err != nil {
+			return nil, err
 		}
-		return nil
+		return &ResolvedAddress{Host: address, IPs: []net.IP{ip}, ResolvedAt: now}, nil
 	}
 
 	// resolve hostname
 	ips, err := net.LookupIP(address)
 	if err != nil {
-		// If DNS resolution fails, we'll allow it and let the connection fail later
-		// This prevents DNS issues from blocking valid configs
-		return nil
+		if cfg.Strict {
+			return nil, fmt.Errorf("%w: failed to resolve %s: %v", ErrLocalhostBlocked, address, err)
+		}
+		// Permissive fallback: let the connection fail later rather than
+		// block a config over a transient DNS issue.
+		return &ResolvedAddress{Host: address, ResolvedAt: now}, nil
+	}
+
+	maxIPs := cfg.MaxResolvedIPs
+	if maxIPs <= 0 {
+		maxIPs = defaultMaxResolvedIPs
+	}
+	if len(ips) > maxIPs {
+		ips = ips[:maxIPs]
 	}
 
-	// Check if any resolved IP is localhost
+	vetted := make([]net.IP, 0, len(ips))
 	for _, ip := range ips {
-		if isLocalhostIP(ip) {
+		if err := validateResolvedIP(address, ip, cfg); err != nil {
+			return nil, err
+		}
+		vetted = append(vetted, ip)
+	}
+
+	return &ResolvedAddress{Host: address, IPs: vetted, ResolvedAt: now}, nil
+}
+
+// validateResolvedIP applies the built-in localhost/private checks plus
+// cfg.ExtraDenyCIDRs to a single literal or resolved IP, unmapping an
+// IPv4-mapped IPv6 address first so e.g. ::ffff:127.0.0.1 can't bypass the
+// IPv4 range checks.
+func validateResolvedIP(address string, ip net.IP, cfg AddressValidationConfig) error {
+	unmapped := unmapIP(ip)
+
+	if !cfg.AllowPrivate {
+		if isLocalhostIP(unmapped) {
 			return fmt.Errorf("%w: hostname %s resolves to localhost IP %s", ErrLocalhostBlocked, address, ip)
 		}
-		if isPrivateIP(ip) {
+		if isPrivateIP(unmapped) {
 			return fmt.Errorf("%w: private IP %s", ErrLocalhostBlocked, address)
 		}
 	}
+	for _, denied := range cfg.ExtraDenyCIDRs {
+		if denied.Contains(unmapped) {
+			return fmt.Errorf("%w: denied IP range %s", ErrLocalhostBlocked, address)
+		}
+	}
 
 	return nil
 }
+
+// SafeDialer dials only one of a ResolvedAddress's pinned IPs, refusing to
+// honor whatever the OS connects to if it differs from the pinned set. This
+// is the defense against DNS rebinding between ResolveAddress and connect:
+// the checker must resolve once via ResolveAddress and dial the result via
+// SafeDialer rather than dialing the original hostname again.
+type SafeDialer struct{}
+
+// DialContext dials resolved on port, trying each pinned IP in order and
+// refusing the connection (via the dialer's Control hook) if the address
+// actually being connected to isn't one of resolved.IPs.
+func (d *SafeDialer) DialContext(ctx context.Context, resolved *ResolvedAddress, port string) (net.Conn, error) {
+	if len(resolved.IPs) == 0 {
+		return nil, fmt.Errorf("no pinned IPs for %s", resolved.Host)
+	}
+
+	pinned := make(map[string]bool, len(resolved.IPs))
+	for _, ip := range resolved.IPs {
+		pinned[ip.String()] = true
+	}
+
+	dialer := &net.Dialer{
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !pinned[host] {
+				return fmt.Errorf("refusing to dial %s: not in pinned address set for %s", host, resolved.Host)
+			}
+			return nil
+		},
+	}
+
+	var lastErr error
+	for _, ip := range resolved.IPs {
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("failed to dial any pinned address for %s: %w", resolved.Host, lastErr)
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestResolveAddressLocalhostBlockedByDefault(t *testing.T) {
+	_, err := ResolveAddress("localhost", DefaultAddressValidationConfig())
+	if !errors.Is(err, ErrLocalhostBlocked) {
+		t.Fatalf("ResolveAddress(localhost) error = %v, want ErrLocalhostBlocked", err)
+	}
+}
+
+func TestResolveAddressLocalhostAllowedWithAllowPrivate(t *testing.T) {
+	resolved, err := ResolveAddress("localhost", AddressValidationConfig{AllowPrivate: true})
+	if err != nil {
+		t.Fatalf("ResolveAddress(localhost) with AllowPrivate = %v, want no error", err)
+	}
+	if resolved.Host != "localhost" {
+		t.Fatalf("resolved.Host = %q, want %q", resolved.Host, "localhost")
+	}
+}
+
+func TestResolveAddressLiteralPrivateIPBlockedByDefault(t *testing.T) {
+	_, err := ResolveAddress("192.168.1.1", DefaultAddressValidationConfig())
+	if !errors.Is(err, ErrLocalhostBlocked) {
+		t.Fatalf("ResolveAddress(192.168.1.1) error = %v, want ErrLocalhostBlocked", err)
+	}
+}
+
+func TestResolveAddressLiteralPrivateIPAllowedWithAllowPrivate(t *testing.T) {
+	resolved, err := ResolveAddress("192.168.1.1", AddressValidationConfig{AllowPrivate: true})
+	if err != nil {
+		t.Fatalf("ResolveAddress(192.168.1.1) with AllowPrivate = %v, want no error", err)
+	}
+	if len(resolved.IPs) != 1 || !resolved.IPs[0].Equal(net.IPv4(192, 168, 1, 1)) {
+		t.Fatalf("resolved.IPs = %v, want [192.168.1.1]", resolved.IPs)
+	}
+}
+
+func TestResolveAddressExtraDenyCIDRsAppliesEvenWithAllowPrivate(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("203.0.113.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %v", err)
+	}
+
+	cfg := AddressValidationConfig{AllowPrivate: true, ExtraDenyCIDRs: []*net.IPNet{cidr}}
+	_, err = ResolveAddress("203.0.113.5", cfg)
+	if !errors.Is(err, ErrLocalhostBlocked) {
+		t.Fatalf("ResolveAddress(203.0.113.5) error = %v, want ErrLocalhostBlocked", err)
+	}
+}
+
+func TestResolveAddressAllowedHostsBypassesAllChecks(t *testing.T) {
+	cfg := AddressValidationConfig{AllowedHosts: map[string]bool{"localhost": true}}
+	resolved, err := ResolveAddress("localhost", cfg)
+	if err != nil {
+		t.Fatalf("ResolveAddress(localhost) with AllowedHosts = %v, want no error", err)
+	}
+	if resolved.Host != "localhost" {
+		t.Fatalf("resolved.Host = %q, want %q", resolved.Host, "localhost")
+	}
+}
+
+func TestSafeDialerNoPinnedIPs(t *testing.T) {
+	dialer := &SafeDialer{}
+	_, err := dialer.DialContext(context.Background(), &ResolvedAddress{Host: "example.com"}, "443")
+	if err == nil {
+		t.Fatal("DialContext with no pinned IPs = nil error, want error")
+	}
+}
+
+func TestSafeDialerDialsOnlyPinnedIP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+
+	resolved := &ResolvedAddress{Host: "example.com", IPs: []net.IP{net.IPv4(127, 0, 0, 1)}}
+	dialer := &SafeDialer{}
+	conn, err := dialer.DialContext(context.Background(), resolved, port)
+	if err != nil {
+		t.Fatalf("DialContext to pinned IP = %v, want success", err)
+	}
+	conn.Close()
+}
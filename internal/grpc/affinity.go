@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NamiraNet/namira-core/internal/core"
+	"go.uber.org/zap"
+)
+
+// CheckConfigsWithAffinity routes each config to a checker node in its
+// preferred region - e.g. pinning Iran-origin configs to Iranian nodes and
+// EU configs to EU nodes - without forcing AggregateMode's full fan-out to
+// every node. affinity is called once per config; a false ok, or a region
+// with no healthy matching node, falls back to the region-agnostic weighted
+// balancer instead of failing the config.
+func (g *GRPCCore) CheckConfigsWithAffinity(configs []string, affinity func(cfg string) (region string, ok bool)) <-chan core.CheckResult {
+	resultChan := make(chan core.CheckResult, len(configs))
+
+	go g.processConfigsWithAffinity(configs, affinity, resultChan)
+
+	return resultChan
+}
+
+func (g *GRPCCore) processConfigsWithAffinity(configs []string, affinity func(string) (string, bool), resultChan chan<- core.CheckResult) {
+	defer close(resultChan)
+
+	g.totalRequests.Add(1)
+	g.activeRequests.Add(1)
+	defer g.activeRequests.Add(-1)
+
+	if g.allNodesUnhealthy() {
+		g.logger.Error("All checker nodes are unhealthy, failing affinity request immediately")
+		for _, cfg := range configs {
+			resultChan <- core.CheckResult{
+				Status:         core.CheckResultStatusError,
+				Error:          "all checker nodes are unhealthy",
+				Raw:            cfg,
+				CheckerNodeTag: []string{},
+			}
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	jobID := fmt.Sprintf("grpc-affinity-%d", time.Now().UnixNano())
+
+	g.logger.Info("Starting affinity-routed gRPC config check",
+		zap.String("job_id", jobID),
+		zap.Int("config_count", len(configs)))
+
+	allResults := make(chan core.CheckResult, len(configs))
+	semaphore := make(chan struct{}, g.maxConcurrent)
+
+	var wg sync.WaitGroup
+	for i, cfg := range configs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, config string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			g.processConfigWithAffinity(ctx, jobID, index, config, affinity, allResults)
+		}(i, cfg)
+	}
+
+	g.collectAndForwardResults(allResults, resultChan, &wg)
+}
+
+// processConfigWithAffinity resolves the config's preferred region, picks a
+// node, and dispatches a single-config check to it.
+func (g *GRPCCore) processConfigWithAffinity(ctx context.Context, jobID string, index int, config string, affinity func(string) (string, bool), allResults chan<- core.CheckResult) {
+	region, ok := affinity(config)
+	tag, client := g.selectClientForRegion(region, ok)
+	if client == nil {
+		g.logger.Error("No available checker clients for affinity request", zap.String("job_id", jobID))
+		allResults <- core.CheckResult{
+			Status:         core.CheckResultStatusError,
+			Error:          "no available checker clients",
+			Raw:            config,
+			CheckerNodeTag: []string{},
+		}
+		return
+	}
+
+	workerJobID := fmt.Sprintf("%s-w%s-c%d", jobID, tag, index)
+
+	tracker := g.workerTrackerFor(tag)
+	if tracker != nil && !tracker.breaker.admit() {
+		g.handleBreakerOpen(workerJobID, tag, config, allResults)
+		return
+	}
+
+	g.recordDispatch(tag)
+	if tracker != nil {
+		tracker.beginInFlight()
+		defer tracker.endInFlight()
+	}
+
+	g.logger.Debug("Sending config to affinity-selected worker",
+		zap.String("worker_job_id", workerJobID),
+		zap.String("worker_tag", tag),
+		zap.String("requested_region", region))
+
+	start := time.Now()
+	grpcResults, err := client.CheckConfigs(ctx, workerJobID, []string{config})
+	if err != nil {
+		g.handleWorkerError(workerJobID, tag, config, err, allResults)
+		return
+	}
+
+	g.processWorkerResults(workerJobID, grpcResults, allResults, tracker, start)
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,82 +11,188 @@ import (
 	"go.uber.org/zap"
 )
 
-// processConfigWithAllWorkers sends a single config to all worker nodes and aggregates results
-func (g *GRPCCore) processConfigWithAllWorkers(ctx context.Context, jobID, config string) core.CheckResult {
+// processConfigWithAllWorkers sends a single config to all worker nodes and
+// aggregates results. When emitPartial is set, it also emits a preliminary
+// result on out as soon as the quorum policy is satisfied, cancelling the
+// remaining in-flight worker RPCs so stragglers don't run to their full
+// timeout before the final, corrected result is returned.
+func (g *GRPCCore) processConfigWithAllWorkers(ctx context.Context, jobID, config string, emitPartial bool, out chan<- core.CheckResult) core.CheckResult {
 	clients, tags := g.getClientsAndTags()
 
 	if len(clients) == 0 {
-		return g.createErrorResult("no available checker clients", config, []string{})
+		result := g.createErrorResult("no available checker clients", config, []string{})
+		result.Complete = true
+		return result
 	}
 
+	// workerCtx is cancelled independently of ctx once quorum is reached, so
+	// straggler RPCs abort promptly without affecting the collector's own
+	// deadline (derived from ctx, not workerCtx) below.
+	workerCtx, cancelWorkers := context.WithCancel(ctx)
+	defer cancelWorkers()
+
 	resultChan := make(chan workerResult, len(clients))
-	g.sendConfigToAllWorkers(ctx, jobID, config, clients, tags, resultChan)
+	g.sendConfigToAllWorkers(workerCtx, jobID, config, clients, tags, resultChan, cancelWorkers)
 
-	return g.collectAndAggregateResults(ctx, jobID, config, clients, resultChan)
+	return g.collectAndAggregateResults(ctx, jobID, config, clients, resultChan, emitPartial, out)
 }
 
-// workerResult represents the result from a single worker
+// workerResult represents the result from a single worker. cancel aborts the
+// in-flight RPCs of every worker still dispatched for this config; it is the
+// same function for every workerResult belonging to one processConfigWithAllWorkers
+// call, threaded through so the collector can invoke it without a separate
+// parameter.
 type workerResult struct {
 	tag    string
 	result *CheckerResponse
 	err    error
+	cancel context.CancelFunc
 }
 
 // sendConfigToAllWorkers sends the config to all workers in parallel
-func (g *GRPCCore) sendConfigToAllWorkers(ctx context.Context, jobID, config string, clients []*CheckerClient, tags []string, resultChan chan<- workerResult) {
+func (g *GRPCCore) sendConfigToAllWorkers(ctx context.Context, jobID, config string, clients []*CheckerClient, tags []string, resultChan chan<- workerResult, cancel context.CancelFunc) {
 	for i, client := range clients {
-		go g.processConfigWithSingleWorker(ctx, jobID, config, client, tags[i], i, resultChan)
+		go g.processConfigWithSingleWorker(ctx, jobID, config, client, tags[i], i, resultChan, cancel)
 	}
 }
 
 // processConfigWithSingleWorker processes config with a single worker
-func (g *GRPCCore) processConfigWithSingleWorker(ctx context.Context, jobID, config string, client *CheckerClient, tag string, workerIndex int, resultChan chan<- workerResult) {
+func (g *GRPCCore) processConfigWithSingleWorker(ctx context.Context, jobID, config string, client *CheckerClient, tag string, workerIndex int, resultChan chan<- workerResult, cancel context.CancelFunc) {
 	workerJobID := fmt.Sprintf("%s-w%d-%s", jobID, workerIndex, tag)
 
+	tracker := g.workerTrackerFor(tag)
+	if tracker != nil && !tracker.breaker.admit() {
+		resultChan <- workerResult{tag: tag, err: fmt.Errorf("checker node %s circuit breaker is open", tag), cancel: cancel}
+		return
+	}
+
+	g.recordDispatch(tag)
+	if tracker != nil {
+		tracker.beginInFlight()
+		defer tracker.endInFlight()
+	}
+
+	start := time.Now()
 	grpcResults, err := client.CheckConfigs(ctx, workerJobID, []string{config})
 	if err != nil {
-		resultChan <- workerResult{tag: tag, err: err}
+		if tracker != nil && ctx.Err() == nil {
+			tracker.recordFailure()
+		}
+		resultChan <- workerResult{tag: tag, err: err, cancel: cancel}
 		return
 	}
 
 	for result := range grpcResults {
 		if result.Status != "CHECKING" && result.Status != "PENDING" {
-			resultChan <- workerResult{tag: result.CheckerNodeTag, result: result}
+			if tracker != nil {
+				if g.isSuccessfulResult(result) {
+					tracker.recordSuccess(time.Since(start))
+				} else if ctx.Err() == nil {
+					// A non-success status after the collector already
+					// cancelled this worker (quorum reached, straggler
+					// aborted) reflects our own cancellation, not a real
+					// failure - don't let it count against the breaker.
+					tracker.recordFailure()
+				}
+			}
+			resultChan <- workerResult{tag: result.CheckerNodeTag, result: result, cancel: cancel}
 			return
 		}
 	}
 
-	resultChan <- workerResult{tag: tag, err: fmt.Errorf("no result received")}
+	if tracker != nil && ctx.Err() == nil {
+		tracker.recordFailure()
+	}
+	resultChan <- workerResult{tag: tag, err: fmt.Errorf("no result received"), cancel: cancel}
 }
 
-// collectAndAggregateResults collects results from all workers and aggregates them
-func (g *GRPCCore) collectAndAggregateResults(ctx context.Context, jobID, config string, clients []*CheckerClient, resultChan <-chan workerResult) core.CheckResult {
+// collectAndAggregateResults collects results from all workers and aggregates
+// them. When emitPartial is set, it emits a preliminary result on out the
+// moment the quorum policy is first satisfied and cancels the remaining
+// stragglers, then keeps collecting (now-fast, cancelled) stragglers and
+// returns the final, corrected result once every worker has reported or ctx
+// is done.
+func (g *GRPCCore) collectAndAggregateResults(ctx context.Context, jobID, config string, clients []*CheckerClient, resultChan <-chan workerResult, emitPartial bool, out chan<- core.CheckResult) core.CheckResult {
 	var successfulResults []workerResult
+	var failedResults []workerResult
 	var totalLatency int64
 	var successfulTags []string
 
-	for i := 0; i < len(clients); i++ {
+	totalWorkers := len(clients)
+	required := g.requiredSuccessfulWorkers(totalWorkers)
+	preliminarySent := false
+
+	for i := 0; i < totalWorkers; i++ {
 		select {
 		case result := <-resultChan:
 			if result.err != nil {
 				g.logWorkerError(jobID, result.tag, config, result.err)
-				continue
-			}
-
-			if g.isSuccessfulResult(result.result) {
+				g.markNodeUnhealthy(result.tag)
+				failedResults = append(failedResults, result)
+			} else if g.isSuccessfulResult(result.result) {
 				successfulResults = append(successfulResults, result)
 				totalLatency += result.result.LatencyMs
 				successfulTags = append(successfulTags, result.result.CheckerNodeTag)
 				g.logWorkerSuccess(jobID, result.result)
 			} else {
 				g.logWorkerFailure(jobID, result.tag, result.result)
+				failedResults = append(failedResults, result)
+			}
+
+			reported := len(successfulResults) + len(failedResults)
+			if emitPartial && !preliminarySent && reported < totalWorkers && len(successfulResults) >= required {
+				// The raw count only satisfies requiredSuccessfulWorkers; the
+				// policy may still reject it (RequireDistinctCountries,
+				// MaxLatencyStddevMs), in which case buildAggregatedResult
+				// returns a quorumFailureResult. Only send a preliminary and
+				// cancel stragglers once the full policy actually passes -
+				// otherwise a straggler that would've fixed the outcome
+				// never gets the chance to report in.
+				candidate := g.buildAggregatedResult(jobID, config, successfulResults, failedResults, totalLatency, successfulTags, totalWorkers)
+				if candidate.Status == core.CheckResultStatusSuccess {
+					candidate.Preliminary = true
+					candidate.WorkersReported = reported
+					out <- candidate
+					preliminarySent = true
+
+					g.logger.Debug("Quorum reached, cancelling remaining workers",
+						zap.String("job_id", jobID),
+						zap.Int("workers_reported", reported),
+						zap.Int("total_workers", totalWorkers))
+					result.cancel()
+				}
 			}
 		case <-ctx.Done():
-			return g.createErrorResult("timeout waiting for worker results", config, successfulTags)
+			if len(successfulResults) == 0 {
+				timeoutResult := g.createErrorResult("timeout waiting for worker results", config, successfulTags)
+				timeoutResult.Complete = true
+				timeoutResult.WorkersReported = len(successfulResults) + len(failedResults)
+				return timeoutResult
+			}
+			return g.finalAggregatedResult(jobID, config, successfulResults, failedResults, totalLatency, successfulTags, totalWorkers, preliminarySent)
 		}
 	}
 
-	return g.buildAggregatedResult(jobID, config, successfulResults, totalLatency, successfulTags, len(clients))
+	return g.finalAggregatedResult(jobID, config, successfulResults, failedResults, totalLatency, successfulTags, totalWorkers, preliminarySent)
+}
+
+// finalAggregatedResult builds the terminal CheckResult for a config: either
+// every worker has reported, or the collector's deadline elapsed with some
+// never responding (including any cancelled as stragglers after a
+// preliminary result was already emitted).
+func (g *GRPCCore) finalAggregatedResult(jobID, config string, successfulResults, failedResults []workerResult, totalLatency int64, successfulTags []string, totalWorkers int, hadPreliminary bool) core.CheckResult {
+	result := g.buildAggregatedResult(jobID, config, successfulResults, failedResults, totalLatency, successfulTags, totalWorkers)
+	result.Complete = true
+	result.WorkersReported = len(successfulResults) + len(failedResults)
+
+	if hadPreliminary {
+		g.logger.Debug("Emitting final corrected result after preliminary quorum result",
+			zap.String("job_id", jobID),
+			zap.Int("workers_reported", result.WorkersReported),
+			zap.Int("total_workers", totalWorkers))
+	}
+
+	return result
 }
 
 // isSuccessfulResult checks if a worker result is successful
@@ -103,12 +210,37 @@ func (g *GRPCCore) createErrorResult(errorMsg, config string, tags []string) cor
 	}
 }
 
-// buildAggregatedResult builds the final aggregated result
-func (g *GRPCCore) buildAggregatedResult(jobID, config string, successfulResults []workerResult, totalLatency int64, successfulTags []string, totalWorkers int) core.CheckResult {
+// buildAggregatedResult builds the final aggregated result, applying the
+// configured QuorumPolicy instead of trusting any single successful worker.
+func (g *GRPCCore) buildAggregatedResult(jobID, config string, successfulResults, failedResults []workerResult, totalLatency int64, successfulTags []string, totalWorkers int) core.CheckResult {
 	if len(successfulResults) == 0 {
 		return g.createErrorResult("all workers failed to validate config", config, []string{})
 	}
 
+	required := g.requiredSuccessfulWorkers(totalWorkers)
+	if len(successfulResults) < required {
+		return g.quorumFailureResult(config, successfulResults, failedResults, totalWorkers,
+			fmt.Sprintf("quorum not met: %d/%d workers reported success, need %d", len(successfulResults), totalWorkers, required))
+	}
+
+	if g.quorumPolicy.RequireDistinctCountries {
+		countries := make(map[string]struct{}, len(successfulResults))
+		for _, r := range successfulResults {
+			countries[r.result.CountryCode] = struct{}{}
+		}
+		if len(countries) < 2 {
+			return g.quorumFailureResult(config, successfulResults, failedResults, totalWorkers,
+				fmt.Sprintf("quorum not met: successful workers span only %d distinct country code(s), need at least 2", len(countries)))
+		}
+	}
+
+	if g.quorumPolicy.MaxLatencyStddevMs > 0 {
+		if stddev := latencyStddevMs(successfulResults); stddev > float64(g.quorumPolicy.MaxLatencyStddevMs) {
+			return g.quorumFailureResult(config, successfulResults, failedResults, totalWorkers,
+				fmt.Sprintf("quorum not met: latency stddev %.1fms exceeds threshold %dms", stddev, g.quorumPolicy.MaxLatencyStddevMs))
+		}
+	}
+
 	baseResult := successfulResults[0].result
 	avgLatency := totalLatency / int64(len(successfulResults))
 
@@ -131,6 +263,29 @@ func (g *GRPCCore) buildAggregatedResult(jobID, config string, successfulResults
 	}
 }
 
+// quorumFailureResult builds an error CheckResult for a config that failed
+// the quorum policy, including a per-worker breakdown in Error so operators
+// can see exactly which nodes disagreed.
+func (g *GRPCCore) quorumFailureResult(config string, successfulResults, failedResults []workerResult, totalWorkers int, reason string) core.CheckResult {
+	tags := make([]string, 0, totalWorkers)
+	breakdown := make([]string, 0, totalWorkers)
+
+	for _, r := range successfulResults {
+		tags = append(tags, r.result.CheckerNodeTag)
+		breakdown = append(breakdown, fmt.Sprintf("%s=success(%dms,%s)", r.result.CheckerNodeTag, r.result.LatencyMs, r.result.CountryCode))
+	}
+	for _, r := range failedResults {
+		tags = append(tags, r.tag)
+		if r.err != nil {
+			breakdown = append(breakdown, fmt.Sprintf("%s=error(%s)", r.tag, r.err))
+		} else {
+			breakdown = append(breakdown, fmt.Sprintf("%s=failed(%s)", r.tag, r.result.Status))
+		}
+	}
+
+	return g.createErrorResult(fmt.Sprintf("%s; per-worker: %s", reason, strings.Join(breakdown, ", ")), config, tags)
+}
+
 // logWorkerError logs worker errors
 func (g *GRPCCore) logWorkerError(jobID, tag, config string, err error) {
 	g.logger.Warn("Worker failed to process config",
@@ -171,8 +326,11 @@ func (g *GRPCCore) collectAndForwardResults(allResults chan core.CheckResult, re
 	}
 }
 
-// processConfigsWithAllWorkers processes multiple configs in parallel, sending each to all workers
-func (g *GRPCCore) processConfigsWithAllWorkers(ctx context.Context, jobID string, configs []string, resultChan chan<- core.CheckResult) {
+// processConfigsWithAllWorkers processes multiple configs in parallel,
+// sending each to all workers. emitPartial controls whether each config's
+// aggregation streams a preliminary result as soon as quorum is reached (see
+// processConfigWithAllWorkers) ahead of the final one.
+func (g *GRPCCore) processConfigsWithAllWorkers(ctx context.Context, jobID string, configs []string, resultChan chan<- core.CheckResult, emitPartial bool) {
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, g.maxConcurrent)
 
@@ -186,7 +344,7 @@ func (g *GRPCCore) processConfigsWithAllWorkers(ctx context.Context, jobID strin
 			defer func() { <-semaphore }()
 
 			configJobID := fmt.Sprintf("%s-c%d", jobID, configIndex)
-			result := g.processConfigWithAllWorkers(ctx, configJobID, config)
+			result := g.processConfigWithAllWorkers(ctx, configJobID, config, emitPartial, resultChan)
 			resultChan <- result
 		}(i, config)
 	}
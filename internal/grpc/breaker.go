@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 10 * time.Second
+)
+
+// workerBreaker is a three-state circuit breaker (closed/open/half-open)
+// guarding one checker worker. After threshold consecutive errors it opens
+// for cooldown; once cooldown elapses it admits exactly one probe request
+// in half-open state - success closes it, failure reopens it for another
+// cooldown.
+type workerBreaker struct {
+	mu                sync.Mutex
+	state             circuitState
+	consecutiveErrors int
+	openedAt          time.Time
+	probing           bool
+	threshold         int
+	cooldown          time.Duration
+}
+
+func newWorkerBreaker() *workerBreaker {
+	return &workerBreaker{threshold: defaultBreakerThreshold, cooldown: defaultBreakerCooldown}
+}
+
+// admit reserves permission to dispatch one request to this worker,
+// transitioning open -> half-open (and reserving its single probe slot)
+// once cooldown has elapsed. Callers that get true must eventually call
+// recordSuccess or recordFailure.
+func (b *workerBreaker) admit() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitClosed:
+		return true
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return false
+	}
+}
+
+// recordSuccess closes the breaker and clears its error count.
+func (b *workerBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = circuitClosed
+	b.consecutiveErrors = 0
+	b.probing = false
+}
+
+// recordFailure counts a consecutive error, opening the breaker once
+// threshold is reached; a failed half-open probe reopens it immediately.
+func (b *workerBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+		b.probing = false
+		b.consecutiveErrors = 0
+		return
+	}
+
+	b.consecutiveErrors++
+	if b.consecutiveErrors >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// available reports whether the breaker is not currently open, for
+// coarse-grained filtering (e.g. getClientsAndTags). It does not reserve
+// the half-open probe slot - use admit for that.
+func (b *workerBreaker) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != circuitOpen {
+		return true
+	}
+	return time.Since(b.openedAt) >= b.cooldown
+}
+
+func (b *workerBreaker) snapshot() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state.String()
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkerBreakerOpensAfterThreshold(t *testing.T) {
+	b := newWorkerBreaker()
+	b.cooldown = time.Hour
+
+	for i := 0; i < defaultBreakerThreshold-1; i++ {
+		if !b.admit() {
+			t.Fatalf("admit() = false before threshold reached (failure %d)", i)
+		}
+		b.recordFailure()
+	}
+
+	if b.snapshot() != "closed" {
+		t.Fatalf("breaker state = %s, want closed before threshold", b.snapshot())
+	}
+
+	if !b.admit() {
+		t.Fatal("admit() = false on the failure that trips the threshold")
+	}
+	b.recordFailure()
+
+	if b.snapshot() != "open" {
+		t.Fatalf("breaker state = %s, want open after %d consecutive failures", b.snapshot(), defaultBreakerThreshold)
+	}
+	if b.admit() {
+		t.Fatal("admit() = true while open and cooldown has not elapsed")
+	}
+}
+
+func TestWorkerBreakerHalfOpenProbe(t *testing.T) {
+	b := newWorkerBreaker()
+	b.cooldown = 0 // cooldown already elapsed
+
+	b.state = circuitOpen
+	b.openedAt = time.Now().Add(-time.Second)
+
+	if !b.admit() {
+		t.Fatal("admit() = false once cooldown has elapsed, want half-open probe admitted")
+	}
+	if b.snapshot() != "half_open" {
+		t.Fatalf("breaker state = %s, want half_open after cooldown elapses", b.snapshot())
+	}
+	if b.admit() {
+		t.Fatal("admit() = true for a second request while a probe is already in flight")
+	}
+}
+
+func TestWorkerBreakerHalfOpenSuccessCloses(t *testing.T) {
+	b := newWorkerBreaker()
+	b.state = circuitHalfOpen
+	b.probing = true
+	b.consecutiveErrors = defaultBreakerThreshold
+
+	b.recordSuccess()
+
+	if b.snapshot() != "closed" {
+		t.Fatalf("breaker state = %s, want closed after a successful probe", b.snapshot())
+	}
+	if !b.admit() {
+		t.Fatal("admit() = false after breaker closed")
+	}
+}
+
+func TestWorkerBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := newWorkerBreaker()
+	b.state = circuitHalfOpen
+	b.probing = true
+
+	b.recordFailure()
+
+	if b.snapshot() != "open" {
+		t.Fatalf("breaker state = %s, want open after a failed probe", b.snapshot())
+	}
+	if time.Since(b.openedAt) > time.Second {
+		t.Fatal("openedAt was not reset on the failed probe")
+	}
+}
+
+func TestWorkerBreakerAvailable(t *testing.T) {
+	b := newWorkerBreaker()
+	b.state = circuitOpen
+	b.cooldown = 10 * time.Millisecond
+	b.openedAt = time.Now()
+
+	if b.available() {
+		t.Fatal("available() = true immediately after opening")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.available() {
+		t.Fatal("available() = false after cooldown has elapsed")
+	}
+}
@@ -2,16 +2,23 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/NamiraNet/namira-core/internal/core/parser"
 	checkerpb "github.com/NamiraNet/namira-core/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -23,12 +30,24 @@ const (
 	configCheckBuffer = 2
 )
 
+// errNoPooledConn means every connection in the pool was unavailable (e.g.
+// mid-recycle) when openStream tried to acquire one. It's transient by
+// nature, so isRetryableErr treats it like codes.Unavailable.
+var errNoPooledConn = errors.New("no pooled connections available")
+
 type CheckerClient struct {
-	conn       *grpc.ClientConn
-	client     checkerpb.ConfigCheckerClient
-	logger     *zap.Logger
-	serverAddr string
-	timeout    time.Duration
+	pool          *checkerConnPool
+	poolSize      int
+	logger        *zap.Logger
+	serverAddr    string
+	timeout       time.Duration
+	apiKey        string
+	tlsConfig     *tls.Config
+	maxConcurrent int
+
+	retryPolicy    RetryPolicy
+	retryAttempts  atomic.Int64
+	retryExhausted atomic.Int64
 
 	// Connection management
 	mu           sync.RWMutex
@@ -36,6 +55,22 @@ type CheckerClient struct {
 	reconnecting bool
 }
 
+// CheckerClientOpts configures optional transport and resiliency settings
+// for a CheckerClient.
+type CheckerClientOpts struct {
+	APIKey        string
+	TLSConfig     *tls.Config
+	RetryPolicy   RetryPolicy
+	PoolSize      int
+	MaxConcurrent int
+}
+
+// RetryStats reports the cumulative retry counters for this client.
+type RetryStats struct {
+	Attempts  int64
+	Exhausted int64
+}
+
 type CheckerResponse struct {
 	JobID       string
 	Config      string
@@ -58,11 +93,31 @@ type CheckerStats struct {
 	UptimeSeconds    int64
 }
 
-func NewCheckerClient(serverAddr string, logger *zap.Logger) (*CheckerClient, error) {
+func NewCheckerClient(serverAddr string, logger *zap.Logger, opts *CheckerClientOpts) (*CheckerClient, error) {
+	if opts == nil {
+		opts = &CheckerClientOpts{}
+	}
+
+	retryPolicy := opts.RetryPolicy
+	if retryPolicy.MaxAttempts == 0 {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
+	poolSize := opts.PoolSize
+	if poolSize <= 0 {
+		poolSize = 1
+	}
+
 	client := &CheckerClient{
-		serverAddr: serverAddr,
-		logger:     logger,
-		timeout:    defaultTimeout,
+		pool:          newCheckerConnPool(),
+		poolSize:      poolSize,
+		serverAddr:    serverAddr,
+		logger:        logger,
+		timeout:       defaultTimeout,
+		apiKey:        opts.APIKey,
+		tlsConfig:     opts.TLSConfig,
+		retryPolicy:   retryPolicy,
+		maxConcurrent: opts.MaxConcurrent,
 	}
 	return client, client.connect()
 }
@@ -75,21 +130,44 @@ func (c *CheckerClient) connect() error {
 		return nil
 	}
 
-	conn, err := c.createConnection()
+	entries, err := c.dialPool()
 	if err != nil {
 		return fmt.Errorf("failed to connect to checker service: %w", err)
 	}
 
-	c.conn = conn
-	c.client = checkerpb.NewConfigCheckerClient(conn)
+	c.pool.set(entries)
 	c.connected = true
-	c.logger.Info("Connected to checker service", zap.String("addr", c.serverAddr))
+	c.logger.Info("Connected to checker service",
+		zap.String("addr", c.serverAddr),
+		zap.Int("pool_size", c.poolSize))
 	return nil
 }
 
+// dialPool dials poolSize connections to serverAddr, closing any already
+// dialed connection if a later one fails.
+func (c *CheckerClient) dialPool() ([]*checkerConnEntry, error) {
+	entries := make([]*checkerConnEntry, 0, c.poolSize)
+	for i := 0; i < c.poolSize; i++ {
+		conn, err := c.createConnection()
+		if err != nil {
+			for _, entry := range entries {
+				entry.conn.Close()
+			}
+			return nil, err
+		}
+		entries = append(entries, &checkerConnEntry{conn: conn, client: checkerpb.NewConfigCheckerClient(conn)})
+	}
+	return entries, nil
+}
+
 func (c *CheckerClient) createConnection() (*grpc.ClientConn, error) {
-	return grpc.NewClient(c.serverAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	transportCreds := insecure.NewCredentials()
+	if c.tlsConfig != nil {
+		transportCreds = credentials.NewTLS(c.tlsConfig)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                keepAliveTime,
 			Timeout:             keepAliveTimeout,
@@ -99,28 +177,284 @@ func (c *CheckerClient) createConnection() (*grpc.ClientConn, error) {
 			grpc.MaxCallRecvMsgSize(defaultBufferSize),
 			grpc.MaxCallSendMsgSize(defaultBufferSize),
 		),
-	)
+		grpc.WithChainUnaryInterceptor(c.apiKeyUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.apiKeyStreamInterceptor),
+	}
+
+	dialer, err := c.pinnedContextDialer()
+	if err != nil {
+		return nil, fmt.Errorf("validate checker address: %w", err)
+	}
+	if dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(dialer))
+	}
+
+	return grpc.NewClient(c.serverAddr, opts...)
 }
 
-func (c *CheckerClient) CheckConfigs(ctx context.Context, jobID string, configs []string) (<-chan *CheckerResponse, error) {
-	if err := c.ensureConnected(); err != nil {
-		return nil, err
+// pinnedContextDialer resolves and vets the host in c.serverAddr once via
+// parser.ResolveAddress, then returns a grpc.WithContextDialer-compatible
+// dialer that only ever connects to one of the pinned IPs via
+// parser.SafeDialer - closing the DNS-rebinding TOCTOU of resolving once to
+// validate and again to connect. A nil dialer (with a nil error) means
+// serverAddr has no vettable host part (e.g. it isn't host:port, or the
+// permissive DNS-failure fall-through left no pinned IPs) and
+// createConnection should fall back to gRPC's own dialing.
+func (c *CheckerClient) pinnedContextDialer() (func(context.Context, string) (net.Conn, error), error) {
+	host, port, err := net.SplitHostPort(c.serverAddr)
+	if err != nil {
+		return nil, nil
 	}
 
-	stream, err := c.client.CheckConfigs(ctx)
+	cfg := parser.DefaultAddressValidationConfig()
+	// Checker nodes are administratively configured infrastructure, commonly
+	// on a private network or localhost (e.g. RAYPING_CHECKER_REATTACH) -
+	// only the rebinding pin is wanted here, not SSRF-style range denial.
+	cfg.AllowPrivate = true
+
+	resolved, err := parser.ResolveAddress(host, cfg)
 	if err != nil {
-		c.logger.Error("Failed to create check stream", zap.Error(err))
+		return nil, err
+	}
+	if len(resolved.IPs) == 0 {
+		return nil, nil
+	}
+
+	dialer := &parser.SafeDialer{}
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, resolved, port)
+	}, nil
+}
+
+// apiKeyUnaryInterceptor attaches the configured API key to outgoing unary RPCs.
+func (c *CheckerClient) apiKeyUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(c.withAPIKey(ctx), method, req, reply, cc, opts...)
+}
+
+// apiKeyStreamInterceptor attaches the configured API key to outgoing streaming RPCs.
+func (c *CheckerClient) apiKeyStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.withAPIKey(ctx), desc, cc, method, opts...)
+}
+
+func (c *CheckerClient) withAPIKey(ctx context.Context) context.Context {
+	if c.apiKey == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "x-api-key", c.apiKey)
+}
+
+func (c *CheckerClient) CheckConfigs(ctx context.Context, jobID string, configs []string) (<-chan *CheckerResponse, error) {
+	if err := c.ensureConnected(); err != nil {
 		return nil, err
 	}
 
 	resultChan := make(chan *CheckerResponse, len(configs)*configCheckBuffer)
-	go c.sendConfigs(ctx, stream, jobID, configs)
-	go c.receiveResponses(ctx, stream, jobID, resultChan)
+	go c.dispatchConfigs(ctx, jobID, configs, resultChan)
 
 	return resultChan, nil
 }
 
-func (c *CheckerClient) sendConfigs(ctx context.Context, stream checkerpb.ConfigChecker_CheckConfigsClient, jobID string, configs []string) {
+// dispatchConfigs shards a config batch across the connection pool so a
+// single HTTP/2 connection can't head-of-line block the whole batch,
+// running at most concurrencyLimit() streams at once.
+func (c *CheckerClient) dispatchConfigs(ctx context.Context, jobID string, configs []string, resultChan chan<- *CheckerResponse) {
+	defer close(resultChan)
+
+	shardCount := min(c.pool.size(), len(configs))
+	if shardCount <= 1 {
+		c.checkConfigsWithRetry(ctx, jobID, configs, resultChan)
+		return
+	}
+
+	shards := shardConfigs(configs, shardCount)
+	semaphore := make(chan struct{}, c.concurrencyLimit())
+
+	var wg sync.WaitGroup
+	for i, shard := range shards {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(shardIndex int, shardConfigs []string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			c.checkConfigsWithRetry(ctx, fmt.Sprintf("%s-s%d", jobID, shardIndex), shardConfigs, resultChan)
+		}(i, shard)
+	}
+	wg.Wait()
+}
+
+// concurrencyLimit bounds how many streams may be in flight across the pool
+// at once; it defaults to the pool size when MaxConcurrent is unset.
+func (c *CheckerClient) concurrencyLimit() int {
+	if c.maxConcurrent > 0 {
+		return c.maxConcurrent
+	}
+	return c.poolSize
+}
+
+// shardConfigs splits configs into n roughly even shards, dealing them out
+// round-robin (config i goes to shard i%n).
+func shardConfigs(configs []string, n int) [][]string {
+	if n <= 0 {
+		n = 1
+	}
+
+	shards := make([][]string, n)
+	for i, config := range configs {
+		idx := i % n
+		shards[idx] = append(shards[idx], config)
+	}
+	return shards
+}
+
+// checkConfigsWithRetry drives one shard over a fresh stream per attempt,
+// transparently retrying configs that were never flushed to the wire when a
+// stream breaks with a transient error. A config that was already sent when
+// the stream fails is reported as an error instead of retried, since the
+// checker may already have produced (and lost) a response for it - retrying
+// it here would risk at-most-once semantics, analogous to grpc-go's
+// PerformedIOError guard on transparent retries.
+func (c *CheckerClient) checkConfigsWithRetry(ctx context.Context, jobID string, configs []string, resultChan chan<- *CheckerResponse) {
+	pending := configs
+
+	for attempt := 0; ; attempt++ {
+		stream, entry, err := c.openStream(ctx, jobID)
+		if err != nil {
+			if !isRetryableErr(err) || attempt+1 >= c.retryPolicy.MaxAttempts {
+				c.retryExhausted.Add(1)
+				c.emitErrors(jobID, pending, err, resultChan)
+				return
+			}
+
+			c.retryAttempts.Add(1)
+			delay := c.retryPolicy.backoff(attempt)
+			c.logger.Warn("Retrying after stream setup failure",
+				zap.String("job_id", jobID),
+				zap.Int("attempt", attempt+1),
+				zap.Duration("delay", delay),
+				zap.Error(err))
+
+			select {
+			case <-time.After(delay):
+				continue
+			case <-ctx.Done():
+				c.emitErrors(jobID, pending, ctx.Err(), resultChan)
+				return
+			}
+		}
+
+		sent := make([]bool, len(pending))
+		answered := make([]bool, len(pending))
+
+		sendErrCh := make(chan error, 1)
+		go func() { sendErrCh <- c.sendConfigs(ctx, stream, jobID, pending, sent) }()
+
+		recvErr := c.receiveResponses(ctx, stream, jobID, resultChan, answered)
+		sendErr := <-sendErrCh
+		c.pool.release(entry)
+
+		failure := recvErr
+		if failure == nil {
+			failure = sendErr
+		}
+		if failure == nil {
+			return
+		}
+
+		var unresolved, unsafeToRetry []string
+		for i, config := range pending {
+			if answered[i] {
+				continue
+			}
+			if sent[i] {
+				unsafeToRetry = append(unsafeToRetry, config)
+			} else {
+				unresolved = append(unresolved, config)
+			}
+		}
+
+		c.emitErrors(jobID, unsafeToRetry, failure, resultChan)
+
+		if len(unresolved) == 0 {
+			return
+		}
+
+		if !isRetryableErr(failure) || attempt+1 >= c.retryPolicy.MaxAttempts {
+			c.retryExhausted.Add(1)
+			c.emitErrors(jobID, unresolved, failure, resultChan)
+			return
+		}
+
+		c.retryAttempts.Add(1)
+		delay := c.retryPolicy.backoff(attempt)
+		c.logger.Warn("Retrying transient stream failure",
+			zap.String("job_id", jobID),
+			zap.Int("attempt", attempt+1),
+			zap.Int("configs_to_retry", len(unresolved)),
+			zap.Duration("delay", delay),
+			zap.Error(failure))
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			c.emitErrors(jobID, unresolved, ctx.Err(), resultChan)
+			return
+		}
+
+		c.recycleEntry(entry)
+
+		pending = unresolved
+	}
+}
+
+// openStream ensures a live connection, acquires the least-loaded pooled
+// connection, and opens a fresh bidi stream on it. The returned entry must
+// be released via c.pool.release once the stream is done.
+func (c *CheckerClient) openStream(ctx context.Context, jobID string) (checkerpb.ConfigChecker_CheckConfigsClient, *checkerConnEntry, error) {
+	if err := c.ensureConnected(); err != nil {
+		return nil, nil, err
+	}
+
+	entry := c.pool.acquire()
+	if entry == nil {
+		return nil, nil, fmt.Errorf("%w for %s", errNoPooledConn, c.serverAddr)
+	}
+
+	stream, err := entry.client.CheckConfigs(ctx)
+	if err != nil {
+		c.pool.release(entry)
+		c.logger.Error("Failed to create check stream", zap.String("job_id", jobID), zap.Error(err))
+		return nil, nil, err
+	}
+	return stream, entry, nil
+}
+
+// emitErrors reports a terminal error for each of the given configs.
+func (c *CheckerClient) emitErrors(jobID string, configs []string, err error, resultChan chan<- *CheckerResponse) {
+	for _, config := range configs {
+		resultChan <- c.errorResponse(jobID, config, err)
+	}
+}
+
+func (c *CheckerClient) errorResponse(jobID, config string, err error) *CheckerResponse {
+	return &CheckerResponse{
+		JobID:     jobID,
+		Config:    config,
+		IsValid:   false,
+		Error:     err.Error(),
+		Status:    "ERROR",
+		Timestamp: time.Now(),
+	}
+}
+
+// RetryStats reports the cumulative retry counters for this client.
+func (c *CheckerClient) RetryStats() RetryStats {
+	return RetryStats{
+		Attempts:  c.retryAttempts.Load(),
+		Exhausted: c.retryExhausted.Load(),
+	}
+}
+
+func (c *CheckerClient) sendConfigs(ctx context.Context, stream checkerpb.ConfigChecker_CheckConfigsClient, jobID string, configs []string, sent []bool) error {
 	defer func() {
 		if err := stream.CloseSend(); err != nil {
 			c.logger.Error("Failed to close check stream", zap.Error(err))
@@ -131,7 +465,7 @@ func (c *CheckerClient) sendConfigs(ctx context.Context, stream checkerpb.Config
 		select {
 		case <-ctx.Done():
 			c.logger.Info("Context canceled, stopping sending configs", zap.String("job_id", jobID))
-			return
+			return ctx.Err()
 		default:
 			req := &checkerpb.CheckRequest{
 				JobId:          jobID,
@@ -145,8 +479,9 @@ func (c *CheckerClient) sendConfigs(ctx context.Context, stream checkerpb.Config
 
 			if err := stream.Send(req); err != nil {
 				c.logger.Error("Failed to send request", zap.Error(err), zap.String("job_id", jobID), zap.Int("config_index", i))
-				return
+				return err
 			}
+			sent[i] = true
 
 			c.logger.Debug("Sent config for checking",
 				zap.String("job_id", jobID),
@@ -156,22 +491,33 @@ func (c *CheckerClient) sendConfigs(ctx context.Context, stream checkerpb.Config
 	}
 
 	c.logger.Info("Sent all configs for checking", zap.String("job_id", jobID), zap.Int("total_configs", len(configs)))
+	return nil
 }
 
-func (c *CheckerClient) receiveResponses(ctx context.Context, stream checkerpb.ConfigChecker_CheckConfigsClient, jobID string, resultChan chan<- *CheckerResponse) {
-	defer close(resultChan)
-
+// receiveResponses reads responses off stream and marks them answered by
+// position: the checker processes one bidi stream as a FIFO, so the nth
+// response corresponds to the nth request sent, even when two pending
+// configs are identical strings (answered used to be keyed by resp.Config,
+// which silently dropped the second of two identical configs from a
+// shard's retry set once the first one's response came back).
+func (c *CheckerClient) receiveResponses(ctx context.Context, stream checkerpb.ConfigChecker_CheckConfigsClient, jobID string, resultChan chan<- *CheckerResponse, answered []bool) error {
+	received := 0
 	for {
 		resp, err := stream.Recv()
 		if err == io.EOF {
 			c.logger.Debug("Stream ended normally", zap.String("job_id", jobID))
-			return
+			return nil
 		}
 		if err != nil {
 			c.logStreamError(jobID, err)
-			return
+			return err
 		}
 
+		if received < len(answered) {
+			answered[received] = true
+		}
+		received++
+
 		result := &CheckerResponse{
 			JobID:       resp.JobId,
 			Config:      resp.Config,
@@ -190,7 +536,7 @@ func (c *CheckerClient) receiveResponses(ctx context.Context, stream checkerpb.C
 		case resultChan <- result:
 		case <-ctx.Done():
 			c.logger.Info("Context cancelled, stopping result processing", zap.String("job_id", jobID))
-			return
+			return ctx.Err()
 		}
 	}
 }
@@ -208,13 +554,25 @@ func (c *CheckerClient) logStreamError(jobID string, err error) {
 	}
 }
 
+// HealthCheck probes every connection in the pool and returns the last
+// error seen, if any.
 func (c *CheckerClient) HealthCheck(ctx context.Context) error {
 	if err := c.ensureConnected(); err != nil {
 		return err
 	}
 
-	_, err := c.client.Health(ctx, &checkerpb.HealthRequest{})
-	return err
+	entries := c.pool.snapshot()
+	if len(entries) == 0 {
+		return fmt.Errorf("no pooled connections for %s", c.serverAddr)
+	}
+
+	var lastErr error
+	for _, entry := range entries {
+		if _, err := entry.client.Health(ctx, &checkerpb.HealthRequest{}); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
 }
 
 func (c *CheckerClient) GetStats(ctx context.Context) (*CheckerStats, error) {
@@ -222,7 +580,13 @@ func (c *CheckerClient) GetStats(ctx context.Context) (*CheckerStats, error) {
 		return nil, err
 	}
 
-	resp, err := c.client.GetStats(ctx, &checkerpb.StatsRequest{})
+	entry := c.pool.acquire()
+	if entry == nil {
+		return nil, fmt.Errorf("no pooled connections for %s", c.serverAddr)
+	}
+	defer c.pool.release(entry)
+
+	resp, err := entry.client.GetStats(ctx, &checkerpb.StatsRequest{})
 	if err != nil {
 		return nil, err
 	}
@@ -258,36 +622,56 @@ func (c *CheckerClient) reconnect() error {
 	c.reconnecting = true
 	defer func() { c.reconnecting = false }()
 
-	if c.conn != nil {
-		c.conn.Close()
-		c.connected = false
-	}
+	c.pool.closeAll()
+	c.connected = false
 
 	c.logger.Info("Reconnecting to checker service", zap.String("addr", c.serverAddr))
 
-	conn, err := c.createConnection()
+	entries, err := c.dialPool()
 	if err != nil {
 		return fmt.Errorf("failed to reconnect to checker service: %w", err)
 	}
 
-	c.conn = conn
-	c.client = checkerpb.NewConfigCheckerClient(conn)
+	c.pool.set(entries)
 	c.connected = true
 
 	c.logger.Info("Successfully reconnected to checker service", zap.String("addr", c.serverAddr))
 	return nil
 }
 
+// recycleEntry redials the single connection behind entry and swaps it into
+// the pool in place, leaving every other pooled connection - and the
+// in-flight streams other goroutines hold on them - untouched. If another
+// goroutine is already recycling this entry, this is a no-op: the caller's
+// own retry will simply pick up whichever connection acquire hands back
+// next.
+func (c *CheckerClient) recycleEntry(entry *checkerConnEntry) {
+	if entry == nil || !entry.recycling.CompareAndSwap(false, true) {
+		return
+	}
+	defer entry.recycling.Store(false)
+
+	conn, err := c.createConnection()
+	if err != nil {
+		c.logger.Warn("Failed to redial checker connection", zap.String("addr", c.serverAddr), zap.Error(err))
+		return
+	}
+
+	newEntry := &checkerConnEntry{conn: conn, client: checkerpb.NewConfigCheckerClient(conn)}
+	if !c.pool.replace(entry, newEntry) {
+		conn.Close()
+		return
+	}
+	entry.conn.Close()
+}
+
+// Close drains and closes every pooled connection.
 func (c *CheckerClient) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.conn != nil {
-		err := c.conn.Close()
-		c.connected = false
-		c.logger.Info("Closed connection to checker service")
-		return err
-	}
-
-	return nil
+	err := c.pool.closeAll()
+	c.connected = false
+	c.logger.Info("Closed connections to checker service", zap.String("addr", c.serverAddr))
+	return err
 }
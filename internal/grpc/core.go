@@ -14,33 +14,86 @@ import (
 )
 
 type GRPCCore struct {
-	clients        []*CheckerClient
-	clientTags     []string
+	clients       []*CheckerClient
+	clientTags    []string
+	clientSources []string
+	sourceByTag   map[string]string
+	weightByTag   map[string]int
+	regionByTag   map[string]string
+	// apiKey and tlsConfig are the startup defaults applied to every
+	// non-reattach node; Reload reuses them for nodes added after startup so
+	// a hot config reload can't silently downgrade a new node to plaintext,
+	// unauthenticated gRPC. Reattach nodes ignore these and build their own
+	// per-node TLS from their handshake instead (see buildReattachTLSConfig).
+	apiKey         string
+	tlsConfig      *tls.Config
+	weighted       *smoothWeightedPicker
+	health         map[string]*nodeHealth
+	trackers       map[string]*workerTracker
 	logger         *zap.Logger
 	timeout        time.Duration
 	maxConcurrent  int
 	aggregateMode  bool // If true, send configs to all workers; if false, distribute efficiently
+	emitPartial    bool // If true, aggregateMode streams a preliminary result once quorum is reached
 	totalRequests  atomic.Int64
 	activeRequests atomic.Int32
-	balanceIndex   atomic.Uint64
+	unhealthyTTL   time.Duration
+	probeInterval  time.Duration
+	probeStop      chan struct{}
+	probeDone      chan struct{}
 	mu             sync.RWMutex
+
+	statsMu        sync.Mutex
+	dispatchCounts map[string]int64
+	regionHits     atomic.Int64
+	regionMisses   atomic.Int64
+
+	quorumPolicy QuorumPolicy
 }
 
 type GRPCCoreOpts struct {
-	CheckerServiceAddr string // Deprecated: use CheckerNodes instead
-	CheckerNodes       []config.CheckerNodeConfig
-	Timeout            time.Duration
-	MaxConcurrent      int
-	AggregateMode      bool // If true, send configs to all workers; if false, distribute efficiently
-	Logger             *zap.Logger
-	APIKey             string
-	TLSConfig          *tls.Config
+	CheckerServiceAddr  string // Deprecated: use CheckerNodes instead
+	CheckerNodes        []config.CheckerNodeConfig
+	Timeout             time.Duration
+	MaxConcurrent       int
+	AggregateMode       bool // If true, send configs to all workers; if false, distribute efficiently
+	EmitPartial         bool // If true, AggregateMode streams a preliminary result once quorum is reached
+	Logger              *zap.Logger
+	APIKey              string
+	TLSConfig           *tls.Config
+	HealthCheckInterval time.Duration
+	UnhealthyTTL        time.Duration
+	RetryPolicy         RetryPolicy
+	QuorumPolicy        QuorumPolicy
 }
 
 type GRPCCoreStats struct {
 	TotalRequests  int64
 	ActiveRequests int32
 	RemoteStats    *CheckerStats
+	NodeHealth     []NodeHealthStat
+	RetryAttempts  int64
+	RetryExhausted int64
+
+	// DispatchCounts reports how many configs have been routed to each tag
+	// since startup, across every dispatch path (plain, aggregate, and
+	// affinity-based).
+	DispatchCounts map[string]int64
+
+	// RegionHits/RegionMisses count CheckConfigsWithAffinity decisions: a
+	// hit found at least one healthy node in the requested region, a miss
+	// fell back to the region-agnostic weighted pool.
+	RegionHits   int64
+	RegionMisses int64
+}
+
+// NodeHealthStat reports the current health balancer state for one checker
+// endpoint, for observability via GetStats.
+type NodeHealthStat struct {
+	Tag       string
+	Source    string // "env", "reattach", "legacy", or "file" - see config.CheckerNodeConfig.Source
+	State     string
+	Ejections int64
 }
 
 func NewGRPCCore(opts *GRPCCoreOpts) (*GRPCCore, error) {
@@ -57,14 +110,53 @@ func NewGRPCCore(opts *GRPCCoreOpts) (*GRPCCore, error) {
 
 	var clients []*CheckerClient
 	var tags []string
+	var sources []string
+	var weights []int
+	var regions []string
 
-	clientOpts := &CheckerClientOpts{
-		APIKey:    opts.APIKey,
-		TLSConfig: opts.TLSConfig,
+	if opts.RetryPolicy.MaxAttempts == 0 {
+		opts.RetryPolicy = DefaultRetryPolicy()
+	}
+	if opts.QuorumPolicy.MinSuccessful == 0 && opts.QuorumPolicy.MinFraction == 0 {
+		opts.QuorumPolicy.MinSuccessful = DefaultQuorumPolicy().MinSuccessful
 	}
+
 	// If using new multi-node configuration
 	if len(opts.CheckerNodes) > 0 {
 		for _, node := range opts.CheckerNodes {
+			poolSize := node.PoolSize
+			if poolSize <= 0 {
+				poolSize = 1
+			}
+
+			apiKey := opts.APIKey
+			tlsConfig := opts.TLSConfig
+			source := node.Source
+			if source == "" {
+				source = "env"
+			}
+
+			// Reattached nodes carry their own TLS/API key material from the
+			// handshake and must not fall back to our own defaults.
+			if source == reattachSource {
+				apiKey = node.APIKey
+				nodeTLS, err := buildReattachTLSConfig(node)
+				if err != nil {
+					opts.Logger.Error("Failed to build TLS config for reattached checker node",
+						zap.String("tag", node.Tag),
+						zap.Error(err))
+					continue
+				}
+				tlsConfig = nodeTLS
+			}
+
+			clientOpts := &CheckerClientOpts{
+				APIKey:        apiKey,
+				TLSConfig:     tlsConfig,
+				RetryPolicy:   opts.RetryPolicy,
+				PoolSize:      poolSize,
+				MaxConcurrent: opts.MaxConcurrent,
+			}
 			client, err := NewCheckerClient(node.Addr, opts.Logger, clientOpts)
 			if err != nil {
 				opts.Logger.Error("Failed to create checker client",
@@ -73,38 +165,225 @@ func NewGRPCCore(opts *GRPCCoreOpts) (*GRPCCore, error) {
 					zap.Error(err))
 				continue
 			}
+			weight := node.Weight
+			if weight <= 0 {
+				weight = 1
+			}
+
 			clients = append(clients, client)
 			tags = append(tags, node.Tag)
+			sources = append(sources, source)
+			weights = append(weights, weight)
+			regions = append(regions, node.Region)
 			opts.Logger.Info("Connected to checker node",
 				zap.String("addr", node.Addr),
-				zap.String("tag", node.Tag))
+				zap.String("tag", node.Tag),
+				zap.String("source", source),
+				zap.Int("weight", weight),
+				zap.String("region", node.Region))
 		}
 	} else if opts.CheckerServiceAddr != "" {
 		// Fallback to legacy single node configuration
-		client, err := NewCheckerClient(opts.CheckerServiceAddr, opts.Logger, clientOpts)
+		client, err := NewCheckerClient(opts.CheckerServiceAddr, opts.Logger, &CheckerClientOpts{
+			APIKey:        opts.APIKey,
+			TLSConfig:     opts.TLSConfig,
+			RetryPolicy:   opts.RetryPolicy,
+			MaxConcurrent: opts.MaxConcurrent,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create checker client: %w", err)
 		}
 		clients = append(clients, client)
 		tags = append(tags, "legacy")
+		sources = append(sources, "legacy")
+		weights = append(weights, 1)
+		regions = append(regions, "")
 	}
 
 	if len(clients) == 0 {
 		return nil, fmt.Errorf("no checker nodes available")
 	}
 
-	return &GRPCCore{
-		clients:       clients,
-		clientTags:    tags,
-		logger:        opts.Logger,
-		timeout:       opts.Timeout,
-		maxConcurrent: opts.MaxConcurrent,
-		aggregateMode: opts.AggregateMode,
-	}, nil
+	if opts.UnhealthyTTL <= 0 {
+		opts.UnhealthyTTL = defaultUnhealthyTTL
+	}
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	health := make(map[string]*nodeHealth, len(tags))
+	sourceByTag := make(map[string]string, len(tags))
+	weightByTag := make(map[string]int, len(tags))
+	regionByTag := make(map[string]string, len(tags))
+	trackers := make(map[string]*workerTracker, len(tags))
+	for i, tag := range tags {
+		health[tag] = newNodeHealth()
+		sourceByTag[tag] = sources[i]
+		weightByTag[tag] = weights[i]
+		regionByTag[tag] = regions[i]
+		trackers[tag] = newWorkerTracker()
+	}
+
+	g := &GRPCCore{
+		clients:        clients,
+		clientTags:     tags,
+		clientSources:  sources,
+		sourceByTag:    sourceByTag,
+		weightByTag:    weightByTag,
+		regionByTag:    regionByTag,
+		weighted:       newSmoothWeightedPicker(),
+		health:         health,
+		trackers:       trackers,
+		dispatchCounts: make(map[string]int64),
+		logger:         opts.Logger,
+		timeout:        opts.Timeout,
+		maxConcurrent:  opts.MaxConcurrent,
+		aggregateMode:  opts.AggregateMode,
+		emitPartial:    opts.EmitPartial,
+		unhealthyTTL:   opts.UnhealthyTTL,
+		probeInterval:  opts.HealthCheckInterval,
+		probeStop:      make(chan struct{}),
+		probeDone:      make(chan struct{}),
+		quorumPolicy:   opts.QuorumPolicy,
+		apiKey:         opts.APIKey,
+		tlsConfig:      opts.TLSConfig,
+	}
+
+	go g.runHealthProber()
+
+	return g, nil
+}
+
+// runHealthProber periodically probes unhealthy nodes with Health RPCs on an
+// exponential schedule and re-admits them on success.
+func (g *GRPCCore) runHealthProber() {
+	defer close(g.probeDone)
+
+	ticker := time.NewTicker(g.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.probeStop:
+			return
+		case <-ticker.C:
+			g.probeUnhealthyNodes()
+		}
+	}
+}
+
+// probeUnhealthyNodes issues a Health RPC against every node whose cooldown
+// has elapsed, re-admitting it into the pool on success.
+func (g *GRPCCore) probeUnhealthyNodes() {
+	// Snapshot every node, not getClientsAndTags: that filters out exactly
+	// the ejected nodes this prober exists to re-admit.
+	g.mu.RLock()
+	clients := make([]*CheckerClient, len(g.clients))
+	tags := make([]string, len(g.clientTags))
+	copy(clients, g.clients)
+	copy(tags, g.clientTags)
+	g.mu.RUnlock()
+
+	for i, client := range clients {
+		tag := tags[i]
+		health := g.nodeHealthFor(tag)
+		if health == nil || !health.dueForProbe() {
+			continue
+		}
+
+		health.beginProbe()
+
+		ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+		err := client.HealthCheck(ctx)
+		cancel()
+
+		if err != nil {
+			delay := health.markProbeFailed()
+			g.logger.Debug("Health probe failed, node remains unhealthy",
+				zap.String("tag", tag),
+				zap.Duration("next_probe", delay),
+				zap.Error(err))
+			continue
+		}
+
+		g.logger.Info("Health probe succeeded, re-admitting node", zap.String("tag", tag))
+		health.markHealthy()
+	}
+}
+
+// nodeHealthFor returns the health tracker for a tag, or nil if unknown.
+func (g *GRPCCore) nodeHealthFor(tag string) *nodeHealth {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.health[tag]
+}
+
+// workerTrackerFor returns the scheduling tracker for a tag, or nil if
+// unknown.
+func (g *GRPCCore) workerTrackerFor(tag string) *workerTracker {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.trackers[tag]
+}
+
+// WorkerStat reports one worker's cost-based scheduling state, for
+// observability via WorkerStats.
+type WorkerStat struct {
+	Tag           string
+	Inflight      int64
+	EWMALatencyMs float64
+	BreakerState  string
+}
+
+// WorkerStats snapshots the scheduling tracker for every known worker.
+func (g *GRPCCore) WorkerStats() []WorkerStat {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	stats := make([]WorkerStat, 0, len(g.trackers))
+	for tag, t := range g.trackers {
+		inflight, latency, breakerState := t.snapshot()
+		stats = append(stats, WorkerStat{
+			Tag:           tag,
+			Inflight:      inflight,
+			EWMALatencyMs: latency,
+			BreakerState:  breakerState,
+		})
+	}
+	return stats
+}
+
+// markNodeUnhealthy ejects the node identified by tag for unhealthyTTL. It is
+// called from the worker dispatch paths on stream errors, connection resets,
+// or deadline-exceeded RPCs, and from periodic HealthCheck failures.
+func (g *GRPCCore) markNodeUnhealthy(tag string) {
+	if health := g.nodeHealthFor(tag); health != nil {
+		health.markUnhealthy(g.unhealthyTTL)
+		g.logger.Warn("Ejected checker node", zap.String("tag", tag), zap.Duration("ttl", g.unhealthyTTL))
+	}
+}
+
+// allNodesUnhealthy reports whether every known checker node is currently
+// ejected, in which case callers should fail fast instead of fanning out.
+func (g *GRPCCore) allNodesUnhealthy() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if len(g.health) == 0 {
+		return false
+	}
+	for _, h := range g.health {
+		if h.available() {
+			return false
+		}
+	}
+	return true
 }
 
 func (g *GRPCCore) CheckConfigs(configs []string) <-chan core.CheckResult {
-	resultChan := make(chan core.CheckResult, len(configs))
+	// Sized for 2 results per config: EmitPartial mode streams a preliminary
+	// result ahead of the final one for configs that reach quorum early.
+	resultChan := make(chan core.CheckResult, len(configs)*2)
 
 	go g.processConfigs(configs, resultChan)
 
@@ -118,6 +397,19 @@ func (g *GRPCCore) processConfigs(configs []string, resultChan chan<- core.Check
 	g.activeRequests.Add(1)
 	defer g.activeRequests.Add(-1)
 
+	if g.allNodesUnhealthy() {
+		g.logger.Error("All checker nodes are unhealthy, failing request immediately")
+		for _, cfg := range configs {
+			resultChan <- core.CheckResult{
+				Status:         core.CheckResultStatusError,
+				Error:          "all checker nodes are unhealthy",
+				Raw:            cfg,
+				CheckerNodeTag: []string{},
+			}
+		}
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
 	defer cancel()
 
@@ -130,7 +422,7 @@ func (g *GRPCCore) processConfigs(configs []string, resultChan chan<- core.Check
 			zap.Int("worker_nodes", len(g.clients)))
 
 		// Send each config to all workers for redundancy and combined results - PARALLEL VERSION
-		g.processConfigsWithAllWorkers(ctx, jobID, configs, resultChan)
+		g.processConfigsWithAllWorkers(ctx, jobID, configs, resultChan, g.emitPartial)
 	} else {
 		g.logger.Info("Starting efficient distributed gRPC config check",
 			zap.String("job_id", jobID),
@@ -168,7 +460,12 @@ func (g *GRPCCore) HealthCheck(ctx context.Context) error {
 				zap.String("addr", client.serverAddr),
 				zap.String("tag", tags[i]),
 				zap.Error(err))
+			g.markNodeUnhealthy(tags[i])
 			lastErr = err
+			continue
+		}
+		if health := g.nodeHealthFor(tags[i]); health != nil {
+			health.markHealthy()
 		}
 	}
 
@@ -187,14 +484,76 @@ func (g *GRPCCore) GetStats(ctx context.Context) (*GRPCCoreStats, error) {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
 
+	retryAttempts, retryExhausted := g.retryStats()
+
 	return &GRPCCoreStats{
 		TotalRequests:  g.totalRequests.Load(),
 		ActiveRequests: g.activeRequests.Load(),
 		RemoteStats:    stats,
+		NodeHealth:     g.nodeHealthStats(),
+		RetryAttempts:  retryAttempts,
+		RetryExhausted: retryExhausted,
+		DispatchCounts: g.dispatchStats(),
+		RegionHits:     g.regionHits.Load(),
+		RegionMisses:   g.regionMisses.Load(),
 	}, nil
 }
 
+// dispatchStats returns a copy of the per-tag dispatch counters.
+func (g *GRPCCore) dispatchStats() map[string]int64 {
+	g.statsMu.Lock()
+	defer g.statsMu.Unlock()
+
+	counts := make(map[string]int64, len(g.dispatchCounts))
+	for tag, n := range g.dispatchCounts {
+		counts[tag] = n
+	}
+	return counts
+}
+
+// recordDispatch increments the dispatch counter for tag, used by every
+// dispatch path (plain, aggregate, affinity) to feed GetStats.
+func (g *GRPCCore) recordDispatch(tag string) {
+	g.statsMu.Lock()
+	defer g.statsMu.Unlock()
+	g.dispatchCounts[tag]++
+}
+
+// retryStats sums the retry counters across every checker client.
+func (g *GRPCCore) retryStats() (attempts, exhausted int64) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, client := range g.clients {
+		stats := client.RetryStats()
+		attempts += stats.Attempts
+		exhausted += stats.Exhausted
+	}
+	return attempts, exhausted
+}
+
+// nodeHealthStats snapshots the health balancer state for every known node.
+func (g *GRPCCore) nodeHealthStats() []NodeHealthStat {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	stats := make([]NodeHealthStat, 0, len(g.health))
+	for tag, h := range g.health {
+		state, ejections := h.snapshot()
+		stats = append(stats, NodeHealthStat{
+			Tag:       tag,
+			Source:    g.sourceByTag[tag],
+			State:     state.String(),
+			Ejections: ejections,
+		})
+	}
+	return stats
+}
+
 func (g *GRPCCore) Close() error {
+	close(g.probeStop)
+	<-g.probeDone
+
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -210,6 +569,12 @@ func (g *GRPCCore) Close() error {
 
 	g.clients = nil
 	g.clientTags = nil
+	g.clientSources = nil
+	g.sourceByTag = nil
+	g.weightByTag = nil
+	g.regionByTag = nil
+	g.health = nil
+	g.trackers = nil
 	return lastErr
 }
 
@@ -239,17 +604,189 @@ func (g *GRPCCore) convertToCheckResult(grpcResult *CheckerResponse) core.CheckR
 	return result
 }
 
-// selectClient returns a checker client using round-robin load balancing
+// selectClient returns a checker client using round-robin load balancing,
+// skipping any node currently ejected by the health balancer.
 func (g *GRPCCore) selectClient() *CheckerClient {
+	_, client := g.selectClientForRegion("", false)
+	return client
+}
+
+// availableWeightsLocked returns tag -> weight for every node not currently
+// ejected by the health balancer or whose circuit breaker is open. Callers
+// must hold g.mu.
+func (g *GRPCCore) availableWeightsLocked() map[string]int {
+	weights := make(map[string]int, len(g.clientTags))
+	for _, tag := range g.clientTags {
+		if health, ok := g.health[tag]; ok && !health.available() {
+			continue
+		}
+		if tracker, ok := g.trackers[tag]; ok && !tracker.breaker.available() {
+			continue
+		}
+		weights[tag] = g.weightByTag[tag]
+	}
+	return weights
+}
+
+// clientByTagLocked returns the client for tag, or nil if unknown. Callers
+// must hold g.mu.
+func (g *GRPCCore) clientByTagLocked(tag string) *CheckerClient {
+	for i, t := range g.clientTags {
+		if t == tag {
+			return g.clients[i]
+		}
+	}
+	return nil
+}
+
+// selectClientForRegion picks a node via weighted smooth round-robin,
+// preferring one whose Region matches when hasRegion is true. If no healthy
+// node matches the region, it falls back to the full weighted pool and
+// counts a region miss; a successful regional match counts a region hit.
+// hasRegion false (or region "") skips region matching entirely and neither
+// counter is touched.
+func (g *GRPCCore) selectClientForRegion(region string, hasRegion bool) (string, *CheckerClient) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	if len(g.clients) == 0 {
-		return nil
+	candidates := g.availableWeightsLocked()
+	if len(candidates) == 0 {
+		return "", nil
 	}
 
-	index := g.balanceIndex.Add(1) % uint64(len(g.clients))
-	return g.clients[index]
+	if hasRegion && region != "" {
+		regional := make(map[string]int, len(candidates))
+		for tag, weight := range candidates {
+			if g.regionByTag[tag] == region {
+				regional[tag] = weight
+			}
+		}
+		if len(regional) > 0 {
+			g.regionHits.Add(1)
+			tag := g.weighted.pick(regional)
+			return tag, g.clientByTagLocked(tag)
+		}
+		g.regionMisses.Add(1)
+	}
+
+	tag := g.weighted.pick(candidates)
+	return tag, g.clientByTagLocked(tag)
+}
+
+// Reload reconciles the running GRPCCore with newCfg.CheckerNodes, adding
+// clients for new tags and closing clients for removed tags in place so
+// operators can add or retire a checker node without restarting the
+// service. Nodes whose tag is unchanged keep their existing connection pool
+// untouched, even if other fields in newCfg differ - changing an existing
+// node's address or credentials requires removing and re-adding its tag.
+func (g *GRPCCore) Reload(newCfg *config.GRPCConfig) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	desired := make(map[string]config.CheckerNodeConfig, len(newCfg.CheckerNodes))
+	for _, node := range newCfg.CheckerNodes {
+		desired[node.Tag] = node
+	}
+
+	existing := make(map[string]int, len(g.clientTags))
+	for i, tag := range g.clientTags {
+		existing[tag] = i
+	}
+
+	var removeErr error
+	var keptClients []*CheckerClient
+	var keptTags []string
+	var keptSources []string
+	for i, tag := range g.clientTags {
+		if _, stillWanted := desired[tag]; stillWanted {
+			keptClients = append(keptClients, g.clients[i])
+			keptTags = append(keptTags, tag)
+			keptSources = append(keptSources, g.clientSources[i])
+			continue
+		}
+		if err := g.clients[i].Close(); err != nil {
+			removeErr = err
+			g.logger.Error("Failed to close checker client during reload", zap.String("tag", tag), zap.Error(err))
+		}
+		delete(g.health, tag)
+		delete(g.sourceByTag, tag)
+		delete(g.weightByTag, tag)
+		delete(g.regionByTag, tag)
+		delete(g.trackers, tag)
+		g.weighted.forget(tag)
+		g.logger.Info("Removed checker node on reload", zap.String("tag", tag))
+	}
+
+	for tag, node := range desired {
+		if _, alreadyRunning := existing[tag]; alreadyRunning {
+			continue
+		}
+
+		poolSize := node.PoolSize
+		if poolSize <= 0 {
+			poolSize = 1
+		}
+
+		apiKey := g.apiKey
+		tlsConfig := g.tlsConfig
+		source := node.Source
+		if source == "" {
+			source = "env"
+		}
+		if source == reattachSource {
+			apiKey = node.APIKey
+			nodeTLS, err := buildReattachTLSConfig(node)
+			if err != nil {
+				g.logger.Error("Failed to build TLS config for reattached checker node on reload",
+					zap.String("tag", tag), zap.Error(err))
+				continue
+			}
+			tlsConfig = nodeTLS
+		}
+
+		client, err := NewCheckerClient(node.Addr, g.logger, &CheckerClientOpts{
+			APIKey:        apiKey,
+			TLSConfig:     tlsConfig,
+			RetryPolicy:   g.retryPolicyOrDefault(),
+			PoolSize:      poolSize,
+			MaxConcurrent: g.maxConcurrent,
+		})
+		if err != nil {
+			g.logger.Error("Failed to add checker node on reload", zap.String("addr", node.Addr), zap.String("tag", tag), zap.Error(err))
+			continue
+		}
+
+		weight := node.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		keptClients = append(keptClients, client)
+		keptTags = append(keptTags, tag)
+		keptSources = append(keptSources, source)
+		g.health[tag] = newNodeHealth()
+		g.sourceByTag[tag] = source
+		g.weightByTag[tag] = weight
+		g.regionByTag[tag] = node.Region
+		g.trackers[tag] = newWorkerTracker()
+		g.logger.Info("Added checker node on reload", zap.String("addr", node.Addr), zap.String("tag", tag), zap.String("source", source))
+	}
+
+	g.clients = keptClients
+	g.clientTags = keptTags
+	g.clientSources = keptSources
+
+	return removeErr
+}
+
+// retryPolicyOrDefault returns the retry policy in effect on the existing
+// clients, falling back to DefaultRetryPolicy if none are left to copy it
+// from (e.g. every node was just removed).
+func (g *GRPCCore) retryPolicyOrDefault() RetryPolicy {
+	if len(g.clients) > 0 {
+		return g.clients[0].retryPolicy
+	}
+	return DefaultRetryPolicy()
 }
 
 // Helper function for min (since it might not be available in older Go versions)
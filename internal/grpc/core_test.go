@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/NamiraNet/namira-core/internal/config"
+	"go.uber.org/zap"
+)
+
+func newTestGRPCCore(t *testing.T, nodes []config.CheckerNodeConfig) *GRPCCore {
+	t.Helper()
+	g, err := NewGRPCCore(&GRPCCoreOpts{
+		CheckerNodes: nodes,
+		Logger:       zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("NewGRPCCore() error = %v", err)
+	}
+	t.Cleanup(func() { g.Close() })
+	return g
+}
+
+func TestReloadAddsAndRemovesNodes(t *testing.T) {
+	g := newTestGRPCCore(t, []config.CheckerNodeConfig{
+		{Addr: "localhost:50051", Tag: "a", Weight: 1},
+		{Addr: "localhost:50052", Tag: "b", Weight: 1},
+	})
+
+	err := g.Reload(&config.GRPCConfig{
+		CheckerNodes: []config.CheckerNodeConfig{
+			{Addr: "localhost:50052", Tag: "b", Weight: 2},
+			{Addr: "localhost:50053", Tag: "c", Weight: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if len(g.clientTags) != 2 {
+		t.Fatalf("clientTags = %v, want 2 entries after reload", g.clientTags)
+	}
+	tags := map[string]bool{}
+	for _, tag := range g.clientTags {
+		tags[tag] = true
+	}
+	if tags["a"] {
+		t.Fatal("node \"a\" should have been removed by reload")
+	}
+	if !tags["b"] || !tags["c"] {
+		t.Fatalf("want nodes b and c present, got %v", tags)
+	}
+	if _, ok := g.health["a"]; ok {
+		t.Fatal("health state for removed node \"a\" should have been cleaned up")
+	}
+	if _, ok := g.trackers["c"]; !ok {
+		t.Fatal("newly added node \"c\" should have a tracker")
+	}
+}
+
+func TestReloadKeepsUntouchedNodeClientInPlace(t *testing.T) {
+	g := newTestGRPCCore(t, []config.CheckerNodeConfig{
+		{Addr: "localhost:50051", Tag: "a", Weight: 1},
+	})
+
+	g.mu.RLock()
+	originalClient := g.clients[0]
+	g.mu.RUnlock()
+
+	if err := g.Reload(&config.GRPCConfig{
+		CheckerNodes: []config.CheckerNodeConfig{
+			{Addr: "localhost:50051", Tag: "a", Weight: 1},
+		},
+	}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.clients[0] != originalClient {
+		t.Fatal("Reload() tore down and recreated a client whose node config was unchanged")
+	}
+}
+
+func TestReloadReusesStartupTLSAndAPIKeyForNewNodes(t *testing.T) {
+	g := newTestGRPCCore(t, []config.CheckerNodeConfig{
+		{Addr: "localhost:50051", Tag: "a", Weight: 1},
+	})
+	g.apiKey = "startup-key"
+
+	if err := g.Reload(&config.GRPCConfig{
+		CheckerNodes: []config.CheckerNodeConfig{
+			{Addr: "localhost:50051", Tag: "a", Weight: 1},
+			{Addr: "localhost:50052", Tag: "b", Weight: 1},
+		},
+	}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for i, tag := range g.clientTags {
+		if tag == "b" {
+			if g.clients[i].apiKey != "startup-key" {
+				t.Fatalf("node added by reload got apiKey %q, want the startup default", g.clients[i].apiKey)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// nodeHealthState is the health state of a single checker endpoint, modeled
+// after etcd clientv3's health balancer: an endpoint starts healthy, gets
+// ejected to unhealthy on failure, and is re-admitted once a background
+// probe succeeds.
+type nodeHealthState int
+
+const (
+	nodeHealthy nodeHealthState = iota
+	nodeUnhealthy
+	nodeProbing
+)
+
+func (s nodeHealthState) String() string {
+	switch s {
+	case nodeHealthy:
+		return "healthy"
+	case nodeUnhealthy:
+		return "unhealthy"
+	case nodeProbing:
+		return "probing"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	defaultUnhealthyTTL        = 5 * time.Second
+	defaultHealthCheckInterval = 10 * time.Second
+	probeBackoffBase           = 500 * time.Millisecond
+	probeBackoffMax            = 30 * time.Second
+)
+
+// nodeHealth tracks the health of a single checker endpoint. Failures
+// observed in CheckConfigs or periodic HealthCheck calls eject the node for
+// unhealthyTTL; a background prober re-admits it on a successful Health RPC,
+// backing off exponentially between probe attempts.
+type nodeHealth struct {
+	mu             sync.Mutex
+	state          nodeHealthState
+	unhealthyUntil time.Time
+	probeAttempt   int
+	ejections      int64
+}
+
+func newNodeHealth() *nodeHealth {
+	return &nodeHealth{state: nodeHealthy}
+}
+
+// markUnhealthy ejects the node for ttl and records an ejection event.
+func (n *nodeHealth) markUnhealthy(ttl time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.state = nodeUnhealthy
+	n.unhealthyUntil = time.Now().Add(ttl)
+	n.probeAttempt = 0
+	n.ejections++
+}
+
+// beginProbe marks the node as currently being probed so selectClient keeps
+// skipping it while the probe RPC is in flight.
+func (n *nodeHealth) beginProbe() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.state = nodeProbing
+}
+
+// markHealthy re-admits the node after a successful probe or check.
+func (n *nodeHealth) markHealthy() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.state = nodeHealthy
+	n.probeAttempt = 0
+}
+
+// available reports whether the node may currently receive traffic.
+func (n *nodeHealth) available() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.state == nodeHealthy
+}
+
+// dueForProbe reports whether an unhealthy node's cooldown has elapsed.
+func (n *nodeHealth) dueForProbe() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.state == nodeUnhealthy && time.Now().After(n.unhealthyUntil)
+}
+
+func (n *nodeHealth) snapshot() (state nodeHealthState, ejections int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.state, n.ejections
+}
+
+// markProbeFailed keeps the node unhealthy after a failed re-admission probe
+// and pushes unhealthyUntil out by an exponentially growing delay (unlike
+// markUnhealthy, it does not reset probeAttempt, so repeated probe failures
+// back off further each time). It returns the delay chosen, for logging.
+func (n *nodeHealth) markProbeFailed() time.Duration {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delay := time.Duration(float64(probeBackoffBase) * math.Pow(2, float64(n.probeAttempt)))
+	if delay <= 0 || delay > probeBackoffMax {
+		delay = probeBackoffMax
+	}
+	n.probeAttempt++
+
+	n.state = nodeUnhealthy
+	n.unhealthyUntil = time.Now().Add(delay)
+	return delay
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNodeHealthStartsHealthy(t *testing.T) {
+	n := newNodeHealth()
+	if !n.available() {
+		t.Fatal("available() = false for a newly created node")
+	}
+	if n.dueForProbe() {
+		t.Fatal("dueForProbe() = true for a healthy node")
+	}
+}
+
+func TestNodeHealthMarkUnhealthyEjects(t *testing.T) {
+	n := newNodeHealth()
+	n.markUnhealthy(time.Hour)
+
+	if n.available() {
+		t.Fatal("available() = true right after ejection")
+	}
+	if n.dueForProbe() {
+		t.Fatal("dueForProbe() = true before the TTL elapses")
+	}
+	if _, ejections := n.snapshot(); ejections != 1 {
+		t.Fatalf("ejections = %d, want 1", ejections)
+	}
+}
+
+func TestNodeHealthDueForProbeAfterTTL(t *testing.T) {
+	n := newNodeHealth()
+	n.markUnhealthy(10 * time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !n.dueForProbe() {
+		t.Fatal("dueForProbe() = false after TTL elapsed")
+	}
+}
+
+func TestNodeHealthBeginProbeStillUnavailable(t *testing.T) {
+	n := newNodeHealth()
+	n.markUnhealthy(0)
+	n.beginProbe()
+
+	if n.available() {
+		t.Fatal("available() = true while a re-admission probe is in flight")
+	}
+	if state, _ := n.snapshot(); state != nodeProbing {
+		t.Fatalf("state = %v, want nodeProbing", state)
+	}
+}
+
+func TestNodeHealthMarkHealthyReadmits(t *testing.T) {
+	n := newNodeHealth()
+	n.markUnhealthy(time.Hour)
+	n.beginProbe()
+	n.markHealthy()
+
+	if !n.available() {
+		t.Fatal("available() = false after a successful probe")
+	}
+}
+
+func TestNodeHealthMarkProbeFailedBacksOffExponentially(t *testing.T) {
+	n := newNodeHealth()
+	n.markUnhealthy(time.Millisecond)
+	n.beginProbe()
+
+	first := n.markProbeFailed()
+	if first != probeBackoffBase {
+		t.Fatalf("first probe failure delay = %v, want %v", first, probeBackoffBase)
+	}
+
+	n.beginProbe()
+	second := n.markProbeFailed()
+	if second != probeBackoffBase*2 {
+		t.Fatalf("second probe failure delay = %v, want %v", second, probeBackoffBase*2)
+	}
+
+	if n.available() {
+		t.Fatal("available() = true while still backed off after a failed probe")
+	}
+}
+
+func TestNodeHealthMarkProbeFailedCapsAtMax(t *testing.T) {
+	n := newNodeHealth()
+	n.markUnhealthy(0)
+	n.probeAttempt = 20
+
+	delay := n.markProbeFailed()
+	if delay != probeBackoffMax {
+		t.Fatalf("delay = %v, want capped at %v", delay, probeBackoffMax)
+	}
+}
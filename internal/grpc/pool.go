@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"sync"
+	"sync/atomic"
+
+	checkerpb "github.com/NamiraNet/namira-core/proto"
+	"google.golang.org/grpc"
+)
+
+// checkerConnEntry is a single pooled connection to a checker node, along
+// with the number of streams currently in flight on it.
+type checkerConnEntry struct {
+	conn     *grpc.ClientConn
+	client   checkerpb.ConfigCheckerClient
+	inflight atomic.Int32
+
+	// recycling guards against two goroutines redialing this same entry
+	// concurrently after a shared stream failure; see CheckerClient.recycleEntry.
+	recycling atomic.Bool
+}
+
+// checkerConnPool holds N *grpc.ClientConn to the same checker address so a
+// single HTTP/2 connection can't head-of-line block a large batch of
+// streams. acquire picks the least-loaded connection, breaking ties
+// round-robin.
+type checkerConnPool struct {
+	mu      sync.RWMutex
+	entries []*checkerConnEntry
+	cursor  atomic.Uint64
+}
+
+func newCheckerConnPool() *checkerConnPool {
+	return &checkerConnPool{}
+}
+
+func (p *checkerConnPool) size() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.entries)
+}
+
+// set replaces the pool's connections, used on initial connect and reconnect.
+func (p *checkerConnPool) set(entries []*checkerConnEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = entries
+}
+
+// acquire returns the least-loaded connection entry and marks it in use.
+// Callers must call release once done with it.
+func (p *checkerConnPool) acquire() *checkerConnEntry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if len(p.entries) == 0 {
+		return nil
+	}
+
+	n := uint64(len(p.entries))
+	start := p.cursor.Add(1) % n
+	best := p.entries[start]
+	for i := uint64(1); i < n; i++ {
+		entry := p.entries[(start+i)%n]
+		if entry.inflight.Load() < best.inflight.Load() {
+			best = entry
+		}
+	}
+
+	best.inflight.Add(1)
+	return best
+}
+
+func (p *checkerConnPool) release(entry *checkerConnEntry) {
+	if entry != nil {
+		entry.inflight.Add(-1)
+	}
+}
+
+// replace swaps oldEntry for newEntry in place, used to recycle a single
+// broken connection without disturbing any other pooled connection's
+// in-flight streams. Returns false if oldEntry is no longer in the pool
+// (e.g. a concurrent Close already cleared it), in which case the caller
+// owns newEntry and must close it.
+func (p *checkerConnPool) replace(oldEntry, newEntry *checkerConnEntry) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, entry := range p.entries {
+		if entry == oldEntry {
+			p.entries[i] = newEntry
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot returns a copy of the current entries, safe to range over without
+// holding the pool lock.
+func (p *checkerConnPool) snapshot() []*checkerConnEntry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	entries := make([]*checkerConnEntry, len(p.entries))
+	copy(entries, p.entries)
+	return entries
+}
+
+// closeAll drains and closes every connection in the pool.
+func (p *checkerConnPool) closeAll() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var lastErr error
+	for _, entry := range p.entries {
+		if err := entry.conn.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	p.entries = nil
+	return lastErr
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import "testing"
+
+func TestCheckerConnPoolAcquirePicksLeastLoaded(t *testing.T) {
+	p := newCheckerConnPool()
+	busy := &checkerConnEntry{}
+	busy.inflight.Store(5)
+	idle := &checkerConnEntry{}
+	p.set([]*checkerConnEntry{busy, idle})
+
+	got := p.acquire()
+	if got != idle {
+		t.Fatalf("acquire() picked the busier entry, want the idle one")
+	}
+	if got.inflight.Load() != 1 {
+		t.Fatalf("acquire() inflight = %d, want 1", got.inflight.Load())
+	}
+}
+
+func TestCheckerConnPoolAcquireEmpty(t *testing.T) {
+	p := newCheckerConnPool()
+	if got := p.acquire(); got != nil {
+		t.Fatalf("acquire() on empty pool = %v, want nil", got)
+	}
+}
+
+func TestCheckerConnPoolRelease(t *testing.T) {
+	p := newCheckerConnPool()
+	entry := &checkerConnEntry{}
+	p.set([]*checkerConnEntry{entry})
+
+	acquired := p.acquire()
+	if acquired.inflight.Load() != 1 {
+		t.Fatalf("inflight after acquire = %d, want 1", acquired.inflight.Load())
+	}
+	p.release(acquired)
+	if acquired.inflight.Load() != 0 {
+		t.Fatalf("inflight after release = %d, want 0", acquired.inflight.Load())
+	}
+}
+
+func TestCheckerConnPoolReplace(t *testing.T) {
+	p := newCheckerConnPool()
+	oldEntry := &checkerConnEntry{}
+	other := &checkerConnEntry{}
+	p.set([]*checkerConnEntry{oldEntry, other})
+
+	newEntry := &checkerConnEntry{}
+	if !p.replace(oldEntry, newEntry) {
+		t.Fatal("replace() = false for an entry still in the pool")
+	}
+
+	entries := p.snapshot()
+	if len(entries) != 2 {
+		t.Fatalf("pool size after replace = %d, want 2", len(entries))
+	}
+	if entries[0] != newEntry && entries[1] != newEntry {
+		t.Fatal("replace() did not swap in the new entry")
+	}
+	if entries[0] == oldEntry || entries[1] == oldEntry {
+		t.Fatal("replace() left the old entry in the pool")
+	}
+	if entries[0] != other && entries[1] != other {
+		t.Fatal("replace() disturbed the other pooled entry")
+	}
+}
+
+func TestCheckerConnPoolReplaceMissing(t *testing.T) {
+	p := newCheckerConnPool()
+	p.set([]*checkerConnEntry{{}})
+
+	if p.replace(&checkerConnEntry{}, &checkerConnEntry{}) {
+		t.Fatal("replace() = true for an entry that is not in the pool")
+	}
+}
+
+func TestCheckerConnPoolSize(t *testing.T) {
+	p := newCheckerConnPool()
+	if p.size() != 0 {
+		t.Fatalf("size() = %d on a new pool, want 0", p.size())
+	}
+	p.set([]*checkerConnEntry{{}, {}})
+	if p.size() != 2 {
+		t.Fatalf("size() = %d, want 2", p.size())
+	}
+}
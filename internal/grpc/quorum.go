@@ -0,0 +1,74 @@
+package grpc
+
+import "math"
+
+// QuorumPolicy governs when collectAndAggregateResults accepts an aggregated
+// config check as successful, instead of trusting any single worker that
+// happens to report SUCCESS.
+type QuorumPolicy struct {
+	// MinSuccessful requires at least this many workers to report SUCCESS.
+	MinSuccessful int
+
+	// MinFraction requires at least this fraction (0-1) of all dispatched
+	// workers to report SUCCESS. The effective requirement is
+	// max(MinSuccessful, ceil(MinFraction*totalWorkers)), so either
+	// threshold alone can't be satisfied below the other.
+	MinFraction float64
+
+	// RequireDistinctCountries, if true, requires the successful workers'
+	// CountryCode values to span at least 2 distinct countries. A single
+	// country reporting success while others fail is often a localized
+	// outage or a compromised node rather than a genuinely reachable config.
+	RequireDistinctCountries bool
+
+	// MaxLatencyStddevMs rejects the aggregate if the standard deviation of
+	// LatencyMs across successful workers exceeds this. Zero disables the
+	// check.
+	MaxLatencyStddevMs int64
+}
+
+// DefaultQuorumPolicy requires only a single successful worker, preserving
+// the historical "any worker succeeds" behavior for callers that don't
+// configure a policy.
+func DefaultQuorumPolicy() QuorumPolicy {
+	return QuorumPolicy{MinSuccessful: 1}
+}
+
+// requiredSuccessfulWorkers returns the minimum number of SUCCESS reports
+// needed out of totalWorkers under the active quorum policy.
+func (g *GRPCCore) requiredSuccessfulWorkers(totalWorkers int) int {
+	required := g.quorumPolicy.MinSuccessful
+	if g.quorumPolicy.MinFraction > 0 {
+		if fractional := int(math.Ceil(g.quorumPolicy.MinFraction * float64(totalWorkers))); fractional > required {
+			required = fractional
+		}
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+// latencyStddevMs computes the population standard deviation of LatencyMs
+// across results, used to flag inconsistent reachability between workers.
+func latencyStddevMs(results []workerResult) float64 {
+	n := float64(len(results))
+	if n == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range results {
+		mean += float64(r.result.LatencyMs)
+	}
+	mean /= n
+
+	var variance float64
+	for _, r := range results {
+		d := float64(r.result.LatencyMs) - mean
+		variance += d * d
+	}
+	variance /= n
+
+	return math.Sqrt(variance)
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import "testing"
+
+func TestRequiredSuccessfulWorkers(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   QuorumPolicy
+		total    int
+		expected int
+	}{
+		{"default policy needs one", DefaultQuorumPolicy(), 5, 1},
+		{"min successful only", QuorumPolicy{MinSuccessful: 3}, 5, 3},
+		{"fraction below min successful", QuorumPolicy{MinSuccessful: 3, MinFraction: 0.2}, 5, 3},
+		{"fraction above min successful, rounds up", QuorumPolicy{MinSuccessful: 1, MinFraction: 0.5}, 5, 3},
+		{"zero min successful floors to one", QuorumPolicy{}, 5, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &GRPCCore{quorumPolicy: tc.policy}
+			if got := g.requiredSuccessfulWorkers(tc.total); got != tc.expected {
+				t.Errorf("requiredSuccessfulWorkers(%d) = %d, want %d", tc.total, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestLatencyStddevMs(t *testing.T) {
+	cases := []struct {
+		name     string
+		latency  []int64
+		expected float64
+	}{
+		{"empty", nil, 0},
+		{"single result", []int64{100}, 0},
+		{"identical latencies", []int64{50, 50, 50}, 0},
+		{"spread latencies", []int64{10, 20, 30}, 8.16496580927726},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := make([]workerResult, len(tc.latency))
+			for i, ms := range tc.latency {
+				results[i] = workerResult{result: &CheckerResponse{LatencyMs: ms}}
+			}
+
+			got := latencyStddevMs(results)
+			const epsilon = 1e-9
+			if diff := got - tc.expected; diff > epsilon || diff < -epsilon {
+				t.Errorf("latencyStddevMs(%v) = %v, want %v", tc.latency, got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/NamiraNet/namira-core/internal/config"
+)
+
+// reattachSource is the config.CheckerNodeConfig.Source value for nodes
+// discovered via RAYPING_CHECKER_REATTACH, analogous to Terraform's
+// TF_REATTACH_PROVIDERS: it lets a developer point rayping at a checker
+// already running under a debugger without restarting rayping.
+const reattachSource = "reattach"
+
+// buildReattachTLSConfig builds the *tls.Config for a reattached node from
+// its own handshake TLS material, so NewGRPCCore can skip its own TLS
+// defaults for that node entirely. A nil, nil return means plaintext.
+func buildReattachTLSConfig(node config.CheckerNodeConfig) (*tls.Config, error) {
+	if node.TLS == nil {
+		return nil, nil
+	}
+	if node.TLS.Insecure {
+		if node.TLS.CAFile != "" || node.TLS.CertFile != "" || node.TLS.KeyFile != "" {
+			return nil, fmt.Errorf("reattached node %q: tls.insecure cannot be combined with ca/cert/key", node.Tag)
+		}
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if node.TLS.CAFile != "" {
+		caCert, err := os.ReadFile(node.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read reattach CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("invalid reattach CA file %s", node.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if node.TLS.CertFile != "" && node.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(node.TLS.CertFile, node.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load reattach client cert: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
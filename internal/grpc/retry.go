@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy controls transparent retry of transient stream failures,
+// defaulting to the same initial delay / max delay / multiplier / jitter
+// gRPC itself uses for its built-in exponential backoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialDelay   time.Duration
+	MaxDelay       time.Duration
+	Multiplier     float64
+	JitterFraction float64
+}
+
+// DefaultRetryPolicy is applied whenever a CheckerClient is built without an
+// explicit policy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialDelay:   time.Second,
+		MaxDelay:       120 * time.Second,
+		Multiplier:     1.6,
+		JitterFraction: 0.2,
+	}
+}
+
+// backoff returns the delay before retry attempt n (0-indexed), with full
+// jitter applied within JitterFraction of the computed delay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxDelay); delay > max {
+		delay = max
+	}
+
+	jitter := delay * p.JitterFraction
+	delay += jitter*rand.Float64()*2 - jitter
+	if delay < 0 {
+		delay = 0
+	}
+
+	return time.Duration(delay)
+}
+
+// isRetryableErr classifies a stream Send/Recv error as transient (safe to
+// retry on a fresh stream) or terminal. Unavailable, DeadlineExceeded,
+// ResourceExhausted and connection-reset I/O errors are retryable; Canceled,
+// InvalidArgument and Unauthenticated are not.
+func isRetryableErr(err error) bool {
+	if err == nil || errors.Is(err, io.EOF) {
+		return false
+	}
+	if errors.Is(err, errNoPooledConn) {
+		return true
+	}
+
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return true
+		case codes.Canceled, codes.InvalidArgument, codes.Unauthenticated:
+			return false
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Best-effort match for connection resets that don't surface as a typed
+	// net.Error through the gRPC transport.
+	msg := err.Error()
+	return strings.Contains(msg, "connection reset") || strings.Contains(msg, "broken pipe")
+}
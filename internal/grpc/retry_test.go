@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicyBackoffGrowsAndCaps(t *testing.T) {
+	p := RetryPolicy{
+		InitialDelay:   time.Second,
+		MaxDelay:       5 * time.Second,
+		Multiplier:     2,
+		JitterFraction: 0, // deterministic
+	}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 5 * time.Second}, // would be 8s uncapped, clamped to MaxDelay
+	}
+
+	for _, tc := range cases {
+		if got := p.backoff(tc.attempt); got != tc.want {
+			t.Errorf("backoff(%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffJitterStaysWithinBounds(t *testing.T) {
+	p := RetryPolicy{
+		InitialDelay:   time.Second,
+		MaxDelay:       10 * time.Second,
+		Multiplier:     1,
+		JitterFraction: 0.2,
+	}
+
+	base := time.Second
+	low := time.Duration(float64(base) * 0.8)
+	high := time.Duration(float64(base) * 1.2)
+
+	for i := 0; i < 50; i++ {
+		got := p.backoff(0)
+		if got < low || got > high {
+			t.Fatalf("backoff(0) = %v, want within [%v, %v]", got, low, high)
+		}
+	}
+}
+
+func TestIsRetryableErr(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"io.EOF", io.EOF, false},
+		{"errNoPooledConn", errNoPooledConn, true},
+		{"unavailable", status.Error(codes.Unavailable, "down"), true},
+		{"deadline exceeded", status.Error(codes.DeadlineExceeded, "timeout"), true},
+		{"resource exhausted", status.Error(codes.ResourceExhausted, "limit"), true},
+		{"canceled", status.Error(codes.Canceled, "cancel"), false},
+		{"invalid argument", status.Error(codes.InvalidArgument, "bad"), false},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "auth"), false},
+		{"connection reset message", errors.New("read: connection reset by peer"), true},
+		{"broken pipe message", errors.New("write: broken pipe"), true},
+		{"context deadline exceeded", context.DeadlineExceeded, true},
+		{"other error", fmt.Errorf("some other failure"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableErr(tc.err); got != tc.want {
+				t.Errorf("isRetryableErr(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
+	"time"
 
 	"github.com/NamiraNet/namira-core/internal/core"
 	"go.uber.org/zap"
@@ -31,15 +33,26 @@ func (g *GRPCCore) distributeConfigsAcrossWorkers(ctx context.Context, jobID str
 		zap.Int("workers_used", len(clients)))
 }
 
-// getClientsAndTags returns copies of clients and tags arrays
+// getClientsAndTags returns copies of the clients and tags arrays,
+// excluding any node currently ejected by the health balancer or whose
+// circuit breaker is open.
 func (g *GRPCCore) getClientsAndTags() ([]*CheckerClient, []string) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	clients := make([]*CheckerClient, len(g.clients))
-	tags := make([]string, len(g.clientTags))
-	copy(clients, g.clients)
-	copy(tags, g.clientTags)
+	clients := make([]*CheckerClient, 0, len(g.clients))
+	tags := make([]string, 0, len(g.clientTags))
+	for i, client := range g.clients {
+		tag := g.clientTags[i]
+		if health, ok := g.health[tag]; ok && !health.available() {
+			continue
+		}
+		if tracker, ok := g.trackers[tag]; ok && !tracker.breaker.available() {
+			continue
+		}
+		clients = append(clients, client)
+		tags = append(tags, tag)
+	}
 
 	return clients, tags
 }
@@ -57,10 +70,11 @@ func (g *GRPCCore) handleNoClients(configs []string, resultChan chan<- core.Chec
 	}
 }
 
-// distributeConfigsToWorkers distributes configs to workers using round-robin
+// distributeConfigsToWorkers distributes configs across workers using the
+// cost-based scheduler (see pickWorkerIndex) instead of plain round-robin.
 func (g *GRPCCore) distributeConfigsToWorkers(ctx context.Context, jobID string, configs []string, clients []*CheckerClient, tags []string, allResults chan<- core.CheckResult, wg *sync.WaitGroup) {
-	for i, config := range configs {
-		clientIndex := i % len(clients)
+	for _, config := range configs {
+		clientIndex := g.pickWorkerIndex(tags)
 		client := clients[clientIndex]
 		tag := tags[clientIndex]
 
@@ -69,24 +83,81 @@ func (g *GRPCCore) distributeConfigsToWorkers(ctx context.Context, jobID string,
 	}
 }
 
-// processConfigWithWorker processes a single config with a specific worker
+// pickWorkerIndex returns the index within tags of the lowest effective-cost
+// worker, among those with a tracker. Effective cost is workerTracker.cost
+// divided by the node's configured Weight (<= 0 treated as 1, matching
+// smoothWeightedPicker), so a weight=3 node needs roughly 3x the raw cost of
+// a weight=1 node before it stops being preferred - the same per-request
+// bias selectClientForRegion gets from smoothWeightedPicker, applied to the
+// cost-based scheduler instead of plain round-robin. Workers without a
+// tracker (shouldn't happen in practice) are ignored; if none have one, it
+// falls back to index 0.
+func (g *GRPCCore) pickWorkerIndex(tags []string) int {
+	g.mu.RLock()
+	weightByTag := make(map[string]int, len(tags))
+	for _, tag := range tags {
+		weightByTag[tag] = g.weightByTag[tag]
+	}
+	g.mu.RUnlock()
+
+	best := 0
+	bestCost := math.Inf(1)
+	found := false
+
+	for i, tag := range tags {
+		tracker := g.workerTrackerFor(tag)
+		if tracker == nil {
+			continue
+		}
+
+		weight := weightByTag[tag]
+		if weight <= 0 {
+			weight = 1
+		}
+		cost := tracker.cost() / float64(weight)
+		if !found || cost < bestCost {
+			best = i
+			bestCost = cost
+			found = true
+		}
+	}
+
+	return best
+}
+
+// processConfigWithWorker processes a single config with a specific worker,
+// gating on its circuit breaker and feeding latency/error samples back into
+// its scheduling tracker.
 func (g *GRPCCore) processConfigWithWorker(ctx context.Context, jobID, config string, client *CheckerClient, tag string, allResults chan<- core.CheckResult, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	workerJobID := fmt.Sprintf("%s-w%s", jobID, tag)
 
+	tracker := g.workerTrackerFor(tag)
+	if tracker != nil && !tracker.breaker.admit() {
+		g.handleBreakerOpen(workerJobID, tag, config, allResults)
+		return
+	}
+
+	g.recordDispatch(tag)
+	if tracker != nil {
+		tracker.beginInFlight()
+		defer tracker.endInFlight()
+	}
+
 	g.logger.Debug("Sending config to worker",
 		zap.String("worker_job_id", workerJobID),
 		zap.String("worker_tag", tag),
 		zap.String("config", config[:min(50, len(config))]))
 
+	start := time.Now()
 	grpcResults, err := client.CheckConfigs(ctx, workerJobID, []string{config})
 	if err != nil {
 		g.handleWorkerError(workerJobID, tag, config, err, allResults)
 		return
 	}
 
-	g.processWorkerResults(workerJobID, grpcResults, allResults)
+	g.processWorkerResults(workerJobID, grpcResults, allResults, tracker, start)
 }
 
 // handleWorkerError handles errors from worker processing
@@ -95,6 +166,10 @@ func (g *GRPCCore) handleWorkerError(workerJobID, tag, config string, err error,
 		zap.String("worker_job_id", workerJobID),
 		zap.String("worker_tag", tag),
 		zap.Error(err))
+	g.markNodeUnhealthy(tag)
+	if tracker := g.workerTrackerFor(tag); tracker != nil {
+		tracker.recordFailure()
+	}
 	allResults <- core.CheckResult{
 		Status:         core.CheckResultStatusError,
 		Error:          err.Error(),
@@ -103,8 +178,27 @@ func (g *GRPCCore) handleWorkerError(workerJobID, tag, config string, err error,
 	}
 }
 
-// processWorkerResults processes results from a worker
-func (g *GRPCCore) processWorkerResults(workerJobID string, grpcResults <-chan *CheckerResponse, allResults chan<- core.CheckResult) {
+// handleBreakerOpen reports an error result for a worker whose circuit
+// breaker declined to admit this request (open, or already probing in
+// half-open state), without counting it as another consecutive failure.
+func (g *GRPCCore) handleBreakerOpen(workerJobID, tag, config string, allResults chan<- core.CheckResult) {
+	g.logger.Debug("Skipping worker with open circuit breaker",
+		zap.String("worker_job_id", workerJobID),
+		zap.String("worker_tag", tag))
+	allResults <- core.CheckResult{
+		Status:         core.CheckResultStatusError,
+		Error:          fmt.Sprintf("checker node %s circuit breaker is open", tag),
+		Raw:            config,
+		CheckerNodeTag: []string{},
+	}
+}
+
+// processWorkerResults forwards a worker's terminal result to allResults and
+// feeds the call's actual outcome - not just that a response arrived - back
+// into tracker, so the circuit breaker and cost-based scheduler don't treat
+// a TIMEOUT/ERROR result (or a channel that closes with no terminal status
+// at all) as a success.
+func (g *GRPCCore) processWorkerResults(workerJobID string, grpcResults <-chan *CheckerResponse, allResults chan<- core.CheckResult, tracker *workerTracker, start time.Time) {
 	for result := range grpcResults {
 		if result.Status != "CHECKING" && result.Status != "PENDING" {
 			coreResult := g.convertToCheckResult(result)
@@ -116,7 +210,19 @@ func (g *GRPCCore) processWorkerResults(workerJobID string, grpcResults <-chan *
 				zap.String("actual_worker_tag", result.CheckerNodeTag),
 				zap.String("status", result.Status),
 				zap.Int64("latency_ms", result.LatencyMs))
-			break
+
+			if tracker != nil {
+				if g.isSuccessfulResult(result) {
+					tracker.recordSuccess(time.Since(start))
+				} else {
+					tracker.recordFailure()
+				}
+			}
+			return
 		}
 	}
+
+	if tracker != nil {
+		tracker.recordFailure()
+	}
 }
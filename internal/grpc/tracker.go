@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ewmaAlpha weights the most recent latency sample against the running
+// average; 0.3 reacts to a node slowing down within a handful of requests
+// without being noisy on single outliers.
+const ewmaAlpha = 0.3
+
+// workerTracker holds the scheduling signals the cost-based balancer uses
+// to pick a worker: in-flight request count, EWMA latency, and a circuit
+// breaker. One tracker exists per checker node tag.
+type workerTracker struct {
+	breaker  *workerBreaker
+	inflight atomic.Int64
+
+	mu          sync.Mutex
+	ewmaLatency float64 // milliseconds; 0 until the first sample
+}
+
+func newWorkerTracker() *workerTracker {
+	return &workerTracker{breaker: newWorkerBreaker()}
+}
+
+func (t *workerTracker) beginInFlight() { t.inflight.Add(1) }
+func (t *workerTracker) endInFlight()   { t.inflight.Add(-1) }
+
+// cost scores this worker for the scheduler: lower is better. Scoring by
+// (inflight+1)*ewmaLatency means a busy or historically slow worker sorts
+// behind an idle, fast one, while a never-used worker (ewmaLatency still 0)
+// sorts first so new or just-recovered workers get a chance to earn a
+// latency sample.
+func (t *workerTracker) cost() float64 {
+	t.mu.Lock()
+	latency := t.ewmaLatency
+	t.mu.Unlock()
+	return float64(t.inflight.Load()+1) * latency
+}
+
+func (t *workerTracker) recordLatency(d time.Duration) {
+	ms := float64(d.Milliseconds())
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ewmaLatency == 0 {
+		t.ewmaLatency = ms
+		return
+	}
+	t.ewmaLatency = ewmaAlpha*ms + (1-ewmaAlpha)*t.ewmaLatency
+}
+
+// recordSuccess feeds a completed request's latency into the EWMA and
+// closes the circuit breaker.
+func (t *workerTracker) recordSuccess(d time.Duration) {
+	t.recordLatency(d)
+	t.breaker.recordSuccess()
+}
+
+// recordFailure feeds a consecutive error into the circuit breaker. Latency
+// is intentionally not sampled here - a failed request's duration isn't a
+// useful signal for how fast a healthy response would have been.
+func (t *workerTracker) recordFailure() {
+	t.breaker.recordFailure()
+}
+
+// snapshot reports the tracker's current scheduling state, for WorkerStats.
+func (t *workerTracker) snapshot() (inflight int64, ewmaLatencyMs float64, breakerState string) {
+	t.mu.Lock()
+	latency := t.ewmaLatency
+	t.mu.Unlock()
+	return t.inflight.Load(), latency, t.breaker.snapshot()
+}
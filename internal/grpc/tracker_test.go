@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkerTrackerCostPrefersIdleAndFast(t *testing.T) {
+	idle := newWorkerTracker()
+	idle.recordLatency(10 * time.Millisecond)
+
+	busy := newWorkerTracker()
+	busy.recordLatency(10 * time.Millisecond)
+	busy.beginInFlight()
+
+	slow := newWorkerTracker()
+	slow.recordLatency(100 * time.Millisecond)
+
+	if idle.cost() >= busy.cost() {
+		t.Fatalf("idle cost %v should be lower than busy cost %v", idle.cost(), busy.cost())
+	}
+	if idle.cost() >= slow.cost() {
+		t.Fatalf("idle cost %v should be lower than slow cost %v", idle.cost(), slow.cost())
+	}
+}
+
+func TestWorkerTrackerCostZeroUntilFirstSample(t *testing.T) {
+	tr := newWorkerTracker()
+	if got := tr.cost(); got != 0 {
+		t.Fatalf("cost() = %v before any latency sample, want 0", got)
+	}
+}
+
+func TestWorkerTrackerRecordLatencyEWMA(t *testing.T) {
+	tr := newWorkerTracker()
+
+	tr.recordLatency(100 * time.Millisecond)
+	if _, latency, _ := tr.snapshot(); latency != 100 {
+		t.Fatalf("first sample: ewmaLatency = %v, want 100", latency)
+	}
+
+	tr.recordLatency(200 * time.Millisecond)
+	want := ewmaAlpha*200 + (1-ewmaAlpha)*100
+	if _, latency, _ := tr.snapshot(); latency != want {
+		t.Fatalf("second sample: ewmaLatency = %v, want %v", latency, want)
+	}
+}
+
+func TestWorkerTrackerRecordSuccessClosesBreaker(t *testing.T) {
+	tr := newWorkerTracker()
+	for i := 0; i < defaultBreakerThreshold; i++ {
+		tr.recordFailure()
+	}
+	if tr.breaker.snapshot() != "open" {
+		t.Fatalf("breaker state = %s, want open after threshold failures", tr.breaker.snapshot())
+	}
+
+	tr.breaker.state = circuitHalfOpen
+	tr.recordSuccess(5 * time.Millisecond)
+
+	if tr.breaker.snapshot() != "closed" {
+		t.Fatalf("breaker state = %s, want closed after recordSuccess", tr.breaker.snapshot())
+	}
+}
+
+func TestWorkerTrackerInFlightCounting(t *testing.T) {
+	tr := newWorkerTracker()
+	tr.beginInFlight()
+	tr.beginInFlight()
+	if inflight, _, _ := tr.snapshot(); inflight != 2 {
+		t.Fatalf("inflight = %d, want 2", inflight)
+	}
+	tr.endInFlight()
+	if inflight, _, _ := tr.snapshot(); inflight != 1 {
+		t.Fatalf("inflight = %d, want 1", inflight)
+	}
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import "sync"
+
+// smoothWeightedPicker implements Nginx-style smooth weighted round-robin:
+// each pick chooses the candidate with the highest current weight, adds its
+// configured weight to every candidate first, then subtracts the total
+// weight from whichever candidate won. This interleaves picks proportional
+// to weight (e.g. weights 3/1/1 repeat the 5-pick cycle A,B,A,C,A, with B and C in either order) instead of
+// bursting through one high-weight node before moving to the next, the way
+// plain modulo round-robin would if a node were just listed three times.
+type smoothWeightedPicker struct {
+	mu      sync.Mutex
+	current map[string]int
+}
+
+func newSmoothWeightedPicker() *smoothWeightedPicker {
+	return &smoothWeightedPicker{current: make(map[string]int)}
+}
+
+// pick selects one tag from candidates (tag -> configured weight, <= 0
+// treated as 1), or "" if candidates is empty.
+func (s *smoothWeightedPicker) pick(candidates map[string]int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	total := 0
+	best := ""
+	bestCurrent := 0
+	for tag, weight := range candidates {
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+		s.current[tag] += weight
+		if best == "" || s.current[tag] > bestCurrent {
+			best = tag
+			bestCurrent = s.current[tag]
+		}
+	}
+
+	s.current[best] -= total
+	return best
+}
+
+// forget drops bookkeeping for a tag that's no longer part of the pool, so
+// it doesn't linger in memory across repeated Reload calls.
+func (s *smoothWeightedPicker) forget(tag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.current, tag)
+}
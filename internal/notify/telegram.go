@@ -4,19 +4,95 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"text/template"
+	"time"
 
 	"github.com/NamiraNet/namira-core/internal/core"
 	"github.com/NamiraNet/namira-core/internal/qr"
 	"github.com/enescakir/emoji"
 )
 
+// Role partitions the bot pool by workload, analogous to teldrive's split
+// between stream and background bots: dedicated bots can be reserved for a
+// workload without starving or being starved by the others.
+type Role string
+
+const (
+	// RoleBroadcast bots send the main channel message/QR posts. They are
+	// also eligible to stand in for RoleQR, so a single-bot deployment
+	// (the common case) keeps working without any role configuration.
+	RoleBroadcast Role = "broadcast"
+	// RoleQR bots are reserved for SendWithQRCode. A RoleBroadcast bot is
+	// used as a fallback if none are configured.
+	RoleQR Role = "qr"
+	// RoleBackground bots are reserved for non-interactive dispatch paths
+	// and are never selected as a RoleBroadcast/RoleQR fallback.
+	RoleBackground Role = "background"
+)
+
+// maxBotAttempts bounds how many distinct bots a single Send/SendWithQRCode
+// call will rotate through on 429s before giving up.
+const maxBotAttempts = 3
+
+// defaultRateLimitCooldown is used when a 429 response carries neither a
+// parseable retry_after body field nor a rate-limit header.
+const defaultRateLimitCooldown = 5 * time.Second
+
 type TelegramBot struct {
 	Token string
 	Name  string
+	Role  Role
+
+	mu        sync.Mutex
+	notBefore time.Time
+	lastUsed  time.Time
+
+	successCount  atomic.Int64
+	failureCount  atomic.Int64
+	cooldownCount atomic.Int64
+}
+
+// available reports whether the bot's cooldown (if any) has elapsed.
+func (b *TelegramBot) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.notBefore)
+}
+
+// cooldownFor puts the bot on cooldown for d, following a 429 response.
+func (b *TelegramBot) cooldownFor(d time.Duration) {
+	b.mu.Lock()
+	b.notBefore = time.Now().Add(d)
+	b.mu.Unlock()
+	b.cooldownCount.Add(1)
+}
+
+func (b *TelegramBot) markUsed() {
+	b.mu.Lock()
+	b.lastUsed = time.Now()
+	b.mu.Unlock()
+}
+
+func (b *TelegramBot) lastUsedAt() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastUsed
+}
+
+// eligibleFor reports whether the bot may serve role. A RoleBroadcast bot
+// also covers RoleQR, so deployments that never call AddBotWithRole keep
+// sending QR posts from the same pool as regular messages; it does not
+// cover RoleBackground, which is always opt-in.
+func (b *TelegramBot) eligibleFor(role Role) bool {
+	if b.Role == role {
+		return true
+	}
+	return b.Role == RoleBroadcast && role == RoleQR
 }
 
 type Telegram struct {
@@ -27,11 +103,10 @@ type Telegram struct {
 	qrGenerator *qr.QRGenerator
 	mu          sync.RWMutex
 	tmpl        *template.Template
-	// Round-robin bot management
-	bots       map[string]*TelegramBot
-	botsList   []*TelegramBot
-	currentBot uint64
-	botsMu     sync.RWMutex
+	// Rate-limit-aware bot pool
+	bots     map[string]*TelegramBot
+	botsList []*TelegramBot
+	botsMu   sync.RWMutex
 }
 
 func NewTelegram(botToken, channel, template, qrConfig string, client *http.Client) *Telegram {
@@ -43,10 +118,9 @@ func NewTelegram(botToken, channel, template, qrConfig string, client *http.Clie
 		qrGenerator: qr.NewQRGenerator(qrConfig),
 		bots:        make(map[string]*TelegramBot),
 		botsList:    make([]*TelegramBot, 0),
-		currentBot:  0,
 	}
 
-	// Add the primary bot token to the bots map
+	// Add the primary bot token to the bots pool
 	if botToken != "" {
 		t.AddBot("primary", botToken)
 	}
@@ -55,21 +129,31 @@ func NewTelegram(botToken, channel, template, qrConfig string, client *http.Clie
 	return t
 }
 
-// AddBot adds a new bot token to the round-robin pool
+// AddBot adds a new bot token to the pool with RoleBroadcast, the default
+// role eligible for both Send and SendWithQRCode.
 func (t *Telegram) AddBot(name, token string) {
+	t.AddBotWithRole(name, token, RoleBroadcast)
+}
+
+// AddBotWithRole adds a new bot token to the pool reserved for role.
+func (t *Telegram) AddBotWithRole(name, token string, role Role) {
 	t.botsMu.Lock()
 	defer t.botsMu.Unlock()
 
 	bot := &TelegramBot{
 		Token: token,
 		Name:  name,
+		Role:  role,
 	}
 
+	if t.bots == nil {
+		t.bots = make(map[string]*TelegramBot)
+	}
 	t.bots[name] = bot
 	t.botsList = append(t.botsList, bot)
 }
 
-// RemoveBot removes a bot from the round-robin pool
+// RemoveBot removes a bot from the pool
 func (t *Telegram) RemoveBot(name string) {
 	t.botsMu.Lock()
 	defer t.botsMu.Unlock()
@@ -88,28 +172,31 @@ func (t *Telegram) RemoveBot(name string) {
 	t.botsList = newBotsList
 }
 
-// getNextBot returns the next bot in round-robin fashion
-func (t *Telegram) getNextBot() *TelegramBot {
+// getNextBot returns the least-recently-used bot eligible for role, skipping
+// any bot in exclude (already tried this call) or still cooling down from a
+// prior 429. It falls back to the primary bot token if the pool is empty,
+// and reports ok=false if every eligible bot is excluded or cooling down.
+func (t *Telegram) getNextBot(role Role, exclude map[string]bool) (*TelegramBot, bool) {
 	t.botsMu.RLock()
 	defer t.botsMu.RUnlock()
 
 	if len(t.botsList) == 0 {
-		// Fallback to primary bot token if no bots in pool
-		return &TelegramBot{
-			Token: t.BotToken,
-			Name:  "fallback",
-		}
+		return &TelegramBot{Token: t.BotToken, Name: "fallback", Role: role}, true
 	}
 
-	if len(t.botsList) == 1 {
-		return t.botsList[0]
+	var best *TelegramBot
+	for _, bot := range t.botsList {
+		if exclude[bot.Name] || !bot.eligibleFor(role) || !bot.available() {
+			continue
+		}
+		if best == nil || bot.lastUsedAt().Before(best.lastUsedAt()) {
+			best = bot
+		}
 	}
-
-	// Atomic increment for thread-safe round-robin
-	current := atomic.AddUint64(&t.currentBot, 1)
-	index := (current - 1) % uint64(len(t.botsList))
-
-	return t.botsList[index]
+	if best == nil {
+		return nil, false
+	}
+	return best, true
 }
 
 // GetBotsCount returns the number of bots in the pool
@@ -129,6 +216,7 @@ func (t *Telegram) ListBots() map[string]*TelegramBot {
 		botsCopy[name] = &TelegramBot{
 			Token: bot.Token,
 			Name:  bot.Name,
+			Role:  bot.Role,
 		}
 	}
 	return botsCopy
@@ -147,6 +235,7 @@ func (t *Telegram) GetBotByName(name string) (*TelegramBot, bool) {
 	return &TelegramBot{
 		Token: bot.Token,
 		Name:  bot.Name,
+		Role:  bot.Role,
 	}, true
 }
 
@@ -157,7 +246,36 @@ func (t *Telegram) ClearBots() {
 
 	t.bots = make(map[string]*TelegramBot)
 	t.botsList = make([]*TelegramBot, 0)
-	atomic.StoreUint64(&t.currentBot, 0)
+}
+
+// BotStat reports one bot's dispatch counters and current cooldown state,
+// for observability via Stats.
+type BotStat struct {
+	Name        string
+	Role        Role
+	Successes   int64
+	Failures    int64
+	Cooldowns   int64
+	CoolingDown bool
+}
+
+// Stats snapshots per-bot success/failure/cooldown counters for the pool.
+func (t *Telegram) Stats() []BotStat {
+	t.botsMu.RLock()
+	defer t.botsMu.RUnlock()
+
+	stats := make([]BotStat, 0, len(t.botsList))
+	for _, bot := range t.botsList {
+		stats = append(stats, BotStat{
+			Name:        bot.Name,
+			Role:        bot.Role,
+			Successes:   bot.successCount.Load(),
+			Failures:    bot.failureCount.Load(),
+			Cooldowns:   bot.cooldownCount.Load(),
+			CoolingDown: !bot.available(),
+		})
+	}
+	return stats
 }
 
 type telegramMessage struct {
@@ -197,6 +315,95 @@ func (t *Telegram) initTemplate() {
 	}
 }
 
+// telegramErrorResponse is the JSON body Telegram returns on a non-2xx
+// response, including the 429 retry_after hint.
+type telegramErrorResponse struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// parseRetryAfter extracts the cooldown duration from a 429 response,
+// preferring the JSON body's retry_after field (Telegram's documented
+// format), falling back to a Retry-After or X-RateLimit-Reset header, and
+// finally defaultRateLimitCooldown if neither is present.
+func parseRetryAfter(resp *http.Response) time.Duration {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err == nil {
+		var errResp telegramErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Parameters.RetryAfter > 0 {
+			return time.Duration(errResp.Parameters.RetryAfter) * time.Second
+		}
+	}
+
+	for _, header := range []string{"Retry-After", "X-RateLimit-Reset"} {
+		if v := resp.Header.Get(header); v != "" {
+			if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+
+	return defaultRateLimitCooldown
+}
+
+// sendWithRetry dispatches newRequest against bots eligible for role,
+// rotating to the next eligible bot (least-recently-used first) on a 429
+// instead of surfacing it immediately. It gives up once maxBotAttempts
+// distinct bots have been tried or no eligible bot remains.
+func (t *Telegram) sendWithRetry(role Role, newRequest func(bot *TelegramBot) (*http.Request, error)) error {
+	tried := make(map[string]bool, maxBotAttempts)
+	var lastErr error
+
+	for attempt := 0; attempt < maxBotAttempts; attempt++ {
+		bot, ok := t.getNextBot(role, tried)
+		if !ok {
+			if lastErr != nil {
+				return lastErr
+			}
+			return fmt.Errorf("no telegram bot available for role %s", role)
+		}
+		tried[bot.Name] = true
+		bot.markUsed()
+
+		req, err := newRequest(bot)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := t.Client.Do(req)
+		if err != nil {
+			bot.failureCount.Add(1)
+			lastErr = fmt.Errorf("failed to send request via bot %s: %w", bot.Name, err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			cooldown := parseRetryAfter(resp)
+			resp.Body.Close()
+			bot.cooldownFor(cooldown)
+			lastErr = fmt.Errorf("telegram API rate limited bot %s, retry after %s", bot.Name, cooldown)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			bot.failureCount.Add(1)
+			lastErr = fmt.Errorf("telegram API returned non-200 status code: %d", resp.StatusCode)
+			continue
+		}
+
+		resp.Body.Close()
+		bot.successCount.Add(1)
+		return nil
+	}
+
+	return fmt.Errorf("exhausted %d bot attempts: %w", maxBotAttempts, lastErr)
+}
+
 func (t *Telegram) Send(result core.CheckResult) error {
 	t.mu.RLock()
 	tmpl := t.tmpl
@@ -217,9 +424,6 @@ func (t *Telegram) Send(result core.CheckResult) error {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	// Next bot in round-robin
-	bot := t.getNextBot()
-
 	jsonData, err := json.Marshal(telegramMessage{
 		ChatID:    t.Channel,
 		Text:      message.String(),
@@ -229,25 +433,16 @@ func (t *Telegram) Send(result core.CheckResult) error {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost,
-		"https://api.telegram.org/bot"+bot.Token+"/sendMessage",
-		bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := t.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("telegram API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return t.sendWithRetry(RoleBroadcast, func(bot *TelegramBot) (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost,
+			"https://api.telegram.org/bot"+bot.Token+"/sendMessage",
+			bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 }
 
 type telegramPhoto struct {
@@ -277,9 +472,6 @@ func (t *Telegram) SendWithQRCode(result core.CheckResult) error {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	// Get the next bot in round-robin fashion
-	bot := t.getNextBot()
-
 	jsonData, err := json.Marshal(telegramPhoto{
 		ChatID:    t.Channel,
 		Photo:     t.qrGenerator.GenerateURL(result.Raw),
@@ -290,23 +482,14 @@ func (t *Telegram) SendWithQRCode(result core.CheckResult) error {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost,
-		"https://api.telegram.org/bot"+bot.Token+"/sendPhoto",
-		bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := t.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send image: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("telegram API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return t.sendWithRetry(RoleQR, func(bot *TelegramBot) (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost,
+			"https://api.telegram.org/bot"+bot.Token+"/sendPhoto",
+			bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 }
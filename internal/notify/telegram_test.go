@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTelegramBotEligibleFor(t *testing.T) {
+	cases := []struct {
+		name string
+		role Role
+		ask  Role
+		want bool
+	}{
+		{"broadcast bot serves broadcast", RoleBroadcast, RoleBroadcast, true},
+		{"broadcast bot falls back for qr", RoleBroadcast, RoleQR, true},
+		{"broadcast bot does not cover background", RoleBroadcast, RoleBackground, false},
+		{"qr bot only serves qr", RoleQR, RoleQR, true},
+		{"qr bot does not serve broadcast", RoleQR, RoleBroadcast, false},
+		{"background bot only serves background", RoleBackground, RoleBackground, true},
+		{"background bot never covers broadcast", RoleBackground, RoleBroadcast, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bot := &TelegramBot{Role: tc.role}
+			if got := bot.eligibleFor(tc.ask); got != tc.want {
+				t.Errorf("eligibleFor(%s) on a %s bot = %v, want %v", tc.ask, tc.role, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTelegramBotCooldown(t *testing.T) {
+	bot := &TelegramBot{}
+	if !bot.available() {
+		t.Fatal("available() = false for a bot with no cooldown set")
+	}
+
+	bot.cooldownFor(20 * time.Millisecond)
+	if bot.available() {
+		t.Fatal("available() = true immediately after cooldownFor")
+	}
+	if bot.cooldownCount.Load() != 1 {
+		t.Fatalf("cooldownCount = %d, want 1", bot.cooldownCount.Load())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !bot.available() {
+		t.Fatal("available() = false after the cooldown elapsed")
+	}
+}
+
+func TestGetNextBotSkipsExcludedAndCoolingDown(t *testing.T) {
+	tg := &Telegram{BotToken: "fallback-token"}
+	tg.AddBotWithRole("a", "token-a", RoleBroadcast)
+	tg.AddBotWithRole("b", "token-b", RoleBroadcast)
+
+	// Put "a" on cooldown directly via the pool's own entry.
+	tg.botsMu.RLock()
+	tg.bots["a"].cooldownFor(time.Hour)
+	tg.botsMu.RUnlock()
+
+	got, ok := tg.getNextBot(RoleBroadcast, nil)
+	if !ok {
+		t.Fatal("getNextBot() = false, want the still-available bot b")
+	}
+	if got.Name != "b" {
+		t.Fatalf("getNextBot() picked %q, want b", got.Name)
+	}
+
+	_, ok = tg.getNextBot(RoleBroadcast, map[string]bool{"b": true})
+	if ok {
+		t.Fatal("getNextBot() = true, want false when every eligible bot is excluded or cooling down")
+	}
+}
+
+func TestGetNextBotFallsBackWhenPoolEmpty(t *testing.T) {
+	tg := &Telegram{BotToken: "fallback-token"}
+
+	got, ok := tg.getNextBot(RoleBroadcast, nil)
+	if !ok {
+		t.Fatal("getNextBot() = false, want fallback bot when the pool is empty")
+	}
+	if got.Token != "fallback-token" {
+		t.Fatalf("fallback bot token = %q, want fallback-token", got.Token)
+	}
+}
+
+func TestSendWithRetryRotatesOnRateLimit(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Query().Get("bot"))
+		if len(calls) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tg := &Telegram{Client: server.Client()}
+	tg.AddBotWithRole("a", "token-a", RoleBroadcast)
+	tg.AddBotWithRole("b", "token-b", RoleBroadcast)
+
+	err := tg.sendWithRetry(RoleBroadcast, func(bot *TelegramBot) (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL+"?bot="+bot.Name, nil)
+	})
+	if err != nil {
+		t.Fatalf("sendWithRetry() error = %v, want nil after rotating to the next bot", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("sendWithRetry() made %d requests, want 2 (one rate-limited, one that succeeds)", len(calls))
+	}
+	if calls[0] == calls[1] {
+		t.Fatalf("sendWithRetry() retried the same bot %q instead of rotating", calls[0])
+	}
+}
+
+func TestSendWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	tg := &Telegram{Client: server.Client()}
+	for i := 0; i < maxBotAttempts; i++ {
+		tg.AddBotWithRole(string(rune('a'+i)), "token", RoleBroadcast)
+	}
+
+	err := tg.sendWithRetry(RoleBroadcast, func(bot *TelegramBot) (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	})
+	if err == nil {
+		t.Fatal("sendWithRetry() error = nil, want an error once every bot attempt is exhausted")
+	}
+}